@@ -10,6 +10,11 @@ type googleSheetsConfig struct {
 	TokenPath             *string  `hcl:"token_path"`
 	SpreadsheetId         *string  `hcl:"spreadsheet_id"`
 	Sheets                []string `hcl:"sheets,optional"`
+	NamedRanges           []string `hcl:"named_ranges,optional"`
+	Tables                []string `hcl:"tables,optional"`
+	SmartChipValues       *bool    `hcl:"smart_chip_values"`
+	RowIdMetadataKey      *string  `hcl:"row_id_metadata_key"`
+	InternalDomains       []string `hcl:"internal_domains,optional"`
 }
 
 func ConfigInstance() interface{} {
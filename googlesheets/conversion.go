@@ -0,0 +1,393 @@
+package googlesheets
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the IANA database, since spreadsheet time zones must resolve on every platform
+	"unicode/utf16"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+// Number format types returned in `effectiveFormat.numberFormat.type`
+const (
+	numberFormatDate     = "DATE"
+	numberFormatDateTime = "DATE_TIME"
+)
+
+// Types of smart chips, based on which properties of the chip are set
+// File and place chips are both represented as rich links
+const (
+	chipTypePerson   = "PERSON"
+	chipTypeRichLink = "RICH_LINK"
+)
+
+// Types of the value held by a cell, based on which field of `effectiveValue` is set
+const (
+	valueTypeNumber = "NUMBER"
+	valueTypeString = "STRING"
+	valueTypeBool   = "BOOL"
+	valueTypeError  = "ERROR"
+)
+
+// Languages whose default decimal separator is a comma
+var commaDecimalLanguages = []string{
+	"af", "az", "be", "bg", "bs", "ca", "cs", "da", "de", "el", "es", "et", "eu", "fi", "fo", "fr", "gl", "hr", "hu", "hy",
+	"id", "is", "it", "ka", "kk", "ky", "lt", "lv", "mk", "mn", "nb", "nl", "nn", "no", "pl", "pt", "ro", "ru", "sk", "sl",
+	"sq", "sr", "sv", "tr", "uk", "uz", "vi",
+}
+
+// Locales that don't follow the default decimal separator of their language
+var decimalSeparatorOverrides = map[string]string{
+	"de_CH": ".",
+	"de_LI": ".",
+	"es_MX": ".",
+	"es_US": ".",
+	"it_CH": ".",
+	"en_ZA": ",",
+}
+
+// spreadsheetSettings holds the spreadsheet level properties required to interpret cell values
+type spreadsheetSettings struct {
+	Location         *time.Location
+	DecimalSeparator string
+
+	// If true, smart chips are rendered as their underlying email or URI instead of their display text
+	SmartChipValues bool
+}
+
+// cellChip describes a smart chip, along with the part of the cell value it is displayed as
+type cellChip struct {
+	Type        string `json:"type"`
+	PersonEmail string `json:"personEmail,omitempty"`
+	Uri         string `json:"uri,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	DisplayText string `json:"displayText"`
+	StartIndex  int64  `json:"startIndex"`
+	EndIndex    int64  `json:"endIndex"`
+}
+
+// newSpreadsheetSettings builds the conversion settings from the `timeZone` and `locale` properties of a spreadsheet
+// Falls back to UTC and a dot decimal separator if the properties are missing or unknown
+func newSpreadsheetSettings(properties *sheets.SpreadsheetProperties) *spreadsheetSettings {
+	settings := &spreadsheetSettings{
+		Location:         time.UTC,
+		DecimalSeparator: ".",
+	}
+	if properties == nil {
+		return settings
+	}
+
+	if properties.TimeZone != "" {
+		if location, err := time.LoadLocation(properties.TimeZone); err == nil {
+			settings.Location = location
+		}
+	}
+	settings.DecimalSeparator = getDecimalSeparator(properties.Locale)
+
+	return settings
+}
+
+// Returns the decimal separator of a locale, e.g. "en_US" uses "." and "de_DE" uses ","
+func getDecimalSeparator(locale string) string {
+	if separator, ok := decimalSeparatorOverrides[locale]; ok {
+		return separator
+	}
+	language := strings.ToLower(strings.SplitN(strings.ReplaceAll(locale, "-", "_"), "_", 2)[0])
+	for _, l := range commaDecimalLanguages {
+		if l == language {
+			return ","
+		}
+	}
+	return "."
+}
+
+// parseLocaleNumber parses a number formatted with the given decimal separator
+// Grouping separators (the other separator, spaces and apostrophes) are ignored, e.g. "45.123,5" is 45123.5 for ","
+func parseLocaleNumber(value string, decimalSeparator string) (float64, error) {
+	groupSeparator := ","
+	if decimalSeparator == "," {
+		groupSeparator = "."
+	}
+
+	replacer := strings.NewReplacer(groupSeparator, "", " ", "", "\u00a0", "", "\u202f", "", "'", "", decimalSeparator, ".")
+	return strconv.ParseFloat(replacer.Replace(strings.TrimSpace(value)), 64)
+}
+
+// serialToTime converts a Sheets serial number into a time in the given location
+// The integer part counts days since 1899-12-30, and the fractional part is the fraction of the day
+// The resulting wall clock time is interpreted in the spreadsheet's time zone
+func serialToTime(serial float64, location *time.Location) time.Time {
+	days := math.Floor(serial)
+
+	// Sheets stores times with millisecond precision, round to avoid floating point drift (e.g. 11:59:59.999)
+	milliseconds := math.Round((serial - days) * 24 * 60 * 60 * 1000)
+
+	return time.Date(1899, time.December, 30+int(days), 0, 0, 0, int(milliseconds)*int(time.Millisecond), location)
+}
+
+// timeToSerial converts a time into a Sheets serial number, using its wall clock time in the given location
+func timeToSerial(t time.Time, location *time.Location) float64 {
+	t = t.In(location)
+	wallClock := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wallClock.Sub(time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+}
+
+// Returns the number format type applied to a cell, if any
+func getNumberFormatType(cell *sheets.CellData) string {
+	if cell == nil || cell.EffectiveFormat == nil || cell.EffectiveFormat.NumberFormat == nil {
+		return ""
+	}
+	return cell.EffectiveFormat.NumberFormat.Type
+}
+
+// getValueType returns the type of the value held by a cell, i.e. NUMBER, STRING, BOOL or ERROR
+// Returns an empty string if the cell has no value
+func getValueType(cell *sheets.CellData) string {
+	if cell == nil || cell.EffectiveValue == nil {
+		return ""
+	}
+
+	switch {
+	case cell.EffectiveValue.ErrorValue != nil:
+		return valueTypeError
+	case cell.EffectiveValue.NumberValue != nil:
+		return valueTypeNumber
+	case cell.EffectiveValue.BoolValue != nil:
+		return valueTypeBool
+	case cell.EffectiveValue.StringValue != nil:
+		return valueTypeString
+	}
+	return ""
+}
+
+// isDateFormat returns true if a number format type holds a calendar date, i.e. DATE or DATE_TIME
+// TIME formatted cells only represent a time of the day (or a duration), and are not converted into timestamps
+func isDateFormat(numberFormatType string) bool {
+	return numberFormatType == numberFormatDate || numberFormatType == numberFormatDateTime
+}
+
+// getCellTimestamp returns the timestamp represented by a DATE or DATE_TIME formatted cell
+// Returns nil if the cell isn't date formatted, or if its value can't be read as a serial number
+func getCellTimestamp(cell *sheets.CellData, settings *spreadsheetSettings) *time.Time {
+	if !isDateFormat(getNumberFormatType(cell)) || cell.EffectiveValue == nil {
+		return nil
+	}
+
+	var serial float64
+	switch {
+	case cell.EffectiveValue.NumberValue != nil:
+		serial = *cell.EffectiveValue.NumberValue
+	case cell.EffectiveValue.StringValue != nil:
+		// Serial numbers entered as text keep the spreadsheet's decimal separator, e.g. "45123,5" in a de_DE spreadsheet
+		value, err := parseLocaleNumber(*cell.EffectiveValue.StringValue, settings.DecimalSeparator)
+		if err != nil {
+			return nil
+		}
+		serial = value
+	default:
+		return nil
+	}
+
+	timestamp := serialToTime(serial, settings.Location)
+	return &timestamp
+}
+
+// inferColumnTypes returns the column type of each column of a sheet, based on the data rows (the header row is skipped)
+// A column is typed as BOOL if it contains checkboxes, and all of its non-empty cells are checkboxes
+// A column is typed as TIMESTAMP if all of its non-empty cells hold a DATE or DATE_TIME formatted value
+// All other columns are returned as STRING
+func inferColumnTypes(rowData []*sheets.RowData, columnCount int) []proto.ColumnType {
+	columnTypes := make([]proto.ColumnType, columnCount)
+	for idx := range columnTypes {
+		columnTypes[idx] = proto.ColumnType_STRING
+	}
+
+	for col := 0; col < columnCount; col++ {
+		hasDate, allDates := false, true
+		hasCheckbox, allCheckboxes := false, true
+		for rowCount, row := range rowData {
+			// Skip first row, or header
+			if rowCount == 0 || row == nil || col >= len(row.Values) {
+				continue
+			}
+			cell := row.Values[col]
+			if cell == nil {
+				continue
+			}
+
+			// Unchecked checkboxes with a single custom value are empty
+			checkbox := isCheckbox(cell)
+			hasCheckbox = hasCheckbox || checkbox
+			if cell.FormattedValue == "" {
+				continue
+			}
+			allCheckboxes = allCheckboxes && checkbox
+
+			if isDateFormat(getNumberFormatType(cell)) && cell.EffectiveValue != nil && cell.EffectiveValue.NumberValue != nil {
+				hasDate = true
+			} else {
+				allDates = false
+			}
+		}
+
+		switch {
+		case hasCheckbox && allCheckboxes:
+			columnTypes[col] = proto.ColumnType_BOOL
+		case hasDate && allDates:
+			columnTypes[col] = proto.ColumnType_TIMESTAMP
+		}
+	}
+
+	return columnTypes
+}
+
+// isCheckbox returns true if a cell is rendered as a checkbox, i.e. it has a BOOLEAN data validation rule
+func isCheckbox(cell *sheets.CellData) bool {
+	return cell != nil && cell.DataValidation != nil && cell.DataValidation.Condition != nil && cell.DataValidation.Condition.Type == "BOOLEAN"
+}
+
+// getCheckboxValue returns the state of a checkbox cell
+// Checkboxes without custom values hold TRUE or FALSE
+// With one custom value, the checkbox is checked if the cell holds that value, and unchecked if the cell is empty
+// With two custom values, the checkbox is checked if the cell holds the first value, and unchecked if it holds the second value
+// Returns nil if the value of the cell doesn't match any state of the checkbox
+func getCheckboxValue(cell *sheets.CellData) *bool {
+	checked, unchecked := true, false
+	if !isCheckbox(cell) {
+		if cell != nil && cell.EffectiveValue != nil {
+			return cell.EffectiveValue.BoolValue
+		}
+		return nil
+	}
+
+	values := getConditionValues(cell.DataValidation.Condition)
+	switch {
+	case len(values) == 0:
+		if cell.EffectiveValue != nil {
+			return cell.EffectiveValue.BoolValue
+		}
+	case strings.EqualFold(cell.FormattedValue, values[0]):
+		return &checked
+	case len(values) == 1 && cell.FormattedValue == "":
+		return &unchecked
+	case len(values) > 1 && strings.EqualFold(cell.FormattedValue, values[1]):
+		return &unchecked
+	}
+	return nil
+}
+
+// getCellChips returns the smart chips of a cell, e.g. people, files and places
+// A chip run applies from its start index (in UTF-16 code units), until the start index of the next run
+func getCellChips(cell *sheets.CellData) []cellChip {
+	if cell == nil {
+		return nil
+	}
+
+	var chips []cellChip
+	value := utf16.Encode([]rune(cell.FormattedValue))
+	for idx, run := range cell.ChipRuns {
+		if run.Chip == nil {
+			continue
+		}
+		startIndex := min(max(run.StartIndex, 0), int64(len(value)))
+		endIndex := int64(len(value))
+		if idx+1 < len(cell.ChipRuns) {
+			endIndex = min(max(cell.ChipRuns[idx+1].StartIndex, startIndex), int64(len(value)))
+		}
+
+		chip := cellChip{
+			DisplayText: string(utf16.Decode(value[startIndex:endIndex])),
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+		}
+		switch {
+		case run.Chip.PersonProperties != nil:
+			chip.Type = chipTypePerson
+			chip.PersonEmail = run.Chip.PersonProperties.Email
+		case run.Chip.RichLinkProperties != nil:
+			chip.Type = chipTypeRichLink
+			chip.Uri = run.Chip.RichLinkProperties.Uri
+			chip.MimeType = run.Chip.RichLinkProperties.MimeType
+		default:
+			continue
+		}
+		chips = append(chips, chip)
+	}
+
+	return chips
+}
+
+// renderChipValues returns the value of a cell where the display text of each smart chip is replaced by its email or URI
+// Text outside of the chips is kept as it is
+func renderChipValues(cell *sheets.CellData) string {
+	chips := getCellChips(cell)
+	if len(chips) == 0 {
+		return cell.FormattedValue
+	}
+
+	value := utf16.Encode([]rune(cell.FormattedValue))
+	var result strings.Builder
+	var index int64
+	for _, chip := range chips {
+		result.WriteString(string(utf16.Decode(value[index:chip.StartIndex])))
+		if chip.Type == chipTypePerson {
+			result.WriteString(chip.PersonEmail)
+		} else {
+			result.WriteString(chip.Uri)
+		}
+		index = chip.EndIndex
+	}
+	result.WriteString(string(utf16.Decode(value[index:])))
+
+	return result.String()
+}
+
+// getColumnValue converts a cell into the value of a dynamic table column of the given type
+func getColumnValue(cell *sheets.CellData, columnType proto.ColumnType, settings *spreadsheetSettings) interface{} {
+	if cell == nil {
+		return nil
+	}
+
+	switch columnType {
+	case proto.ColumnType_BOOL:
+		if value := getCheckboxValue(cell); value != nil {
+			return *value
+		}
+		return nil
+	case proto.ColumnType_TIMESTAMP:
+		if timestamp := getCellTimestamp(cell, settings); timestamp != nil {
+			return *timestamp
+		}
+		return nil
+	case proto.ColumnType_DOUBLE:
+		if cell.EffectiveValue != nil && cell.EffectiveValue.NumberValue != nil {
+			return *cell.EffectiveValue.NumberValue
+		}
+		return nil
+	default:
+		if settings.SmartChipValues {
+			return renderChipValues(cell)
+		}
+		return cell.FormattedValue
+	}
+}
+
+// Returns the column type of a column of a table, based on the column type set in the table definition
+// Time of day columns are returned as text, since they can also hold durations
+func getTableColumnType(columnType string) proto.ColumnType {
+	switch columnType {
+	case "DOUBLE", "CURRENCY", "PERCENT":
+		return proto.ColumnType_DOUBLE
+	case "DATE", "DATE_TIME":
+		return proto.ColumnType_TIMESTAMP
+	case "BOOLEAN":
+		return proto.ColumnType_BOOL
+	default:
+		return proto.ColumnType_STRING
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"slices"
 	"strings"
 
+	"google.golang.org/api/sheets/v4"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -32,15 +34,32 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 	return p
 }
 
-// Map of spreadsheet headers along with the sheet name
+// Map of spreadsheet headers along with the sheet or named range name
 var googleSpreadsheetHeadersMap = map[string][]string{}
 
+// Map of the inferred column types of a sheet or named range, in the same order as its headers
+var googleSpreadsheetColumnTypesMap = map[string][]proto.ColumnType{}
+
 func PluginTables(ctx context.Context, p *plugin.TableMapData) (map[string]*plugin.Table, error) {
 	// Initialize tables
 	tables := map[string]*plugin.Table{}
 
 	/* Static tables */
 	tables["googlesheets_cell"] = tableGoogleSheetsCell(ctx)
+	tables["googlesheets_cell_diff"] = tableGoogleSheetsCellDiff(ctx)
+	tables["googlesheets_cell_link"] = tableGoogleSheetsCellLink(ctx)
+	tables["googlesheets_chart"] = tableGoogleSheetsChart(ctx)
+	tables["googlesheets_comment"] = tableGoogleSheetsComment(ctx)
+	tables["googlesheets_conditional_format"] = tableGoogleSheetsConditionalFormat(ctx)
+	tables["googlesheets_data_validation"] = tableGoogleSheetsDataValidation(ctx)
+	tables["googlesheets_developer_metadata"] = tableGoogleSheetsDeveloperMetadata(ctx)
+	tables["googlesheets_named_range"] = tableGoogleSheetsNamedRange(ctx)
+	tables["googlesheets_permission"] = tableGoogleSheetsPermission(ctx)
+	tables["googlesheets_pivot_table"] = tableGoogleSheetsPivotTable(ctx)
+	tables["googlesheets_protected_range"] = tableGoogleSheetsProtectedRange(ctx)
+	tables["googlesheets_revision"] = tableGoogleSheetsRevision(ctx)
+	tables["googlesheets_sharing_finding"] = tableGoogleSheetsSharingFinding(ctx)
+	tables["googlesheets_validation_violation"] = tableGoogleSheetsValidationViolation(ctx)
 	tables["googlesheets_sheet"] = tableGoogleSheetsSheet(ctx)
 	tables["googlesheets_spreadsheet"] = tableGoogleSheetsSpreadsheet(ctx)
 
@@ -72,21 +91,22 @@ func PluginTables(ctx context.Context, p *plugin.TableMapData) (map[string]*plug
 		return tables, nil
 	}
 
+	// Get the cell data of the sheets, which is used to infer the column types
+	var gridData *sheets.Spreadsheet
+	if len(validSheets) > 0 {
+		gridData, err = getSpreadsheetTypeData(ctx, p, validSheets)
+		if err != nil {
+			// The tables are still created, with all of their columns typed as STRING
+			plugin.Logger(ctx).Warn("PluginTables", "column_types_error", err)
+			gridData = nil
+		}
+	}
+
 	// Create tablemap for all the available sheets
 	for _, sheetName := range validSheets {
 		for _, data := range spreadsheetData {
-			// Return if empty sheet
-			if len(data.Values) == 0 {
-				continue
-			}
-
-			// Return if first row is empty
-			if len(data.Values[0]) == 0 {
-				continue
-			}
-
-			// Return if A1 cell is empty
-			if len(data.Values[0][0].(string)) == 0 {
+			// Return if the header row is empty
+			if !hasHeaderRow(data.Values) {
 				continue
 			}
 
@@ -97,60 +117,35 @@ func PluginTables(ctx context.Context, p *plugin.TableMapData) (map[string]*plug
 				str = str[1 : len(str)-1]
 			}
 
-			var spreadsheetHeaders []string
 			if sheetName == str {
 				mergeCellInfo, _ := getMergeCells(ctx, p, sheetName)
-				maxColsLength := getMaxLength(data.Values)
-				for idx, i := range data.Values[0] {
-					mergeRow, mergeColumn, _, parentColumn := findMergeCells(mergeCellInfo, int64(1), int64(idx+1))
-					if mergeRow != nil && mergeColumn != nil { // Merge cell
-						parentData := data.Values[0][*parentColumn-1]
-						spreadsheetHeaders[len(spreadsheetHeaders)-1] = fmt.Sprintf("%s [%s]", spreadsheetHeaders[len(spreadsheetHeaders)-1], intToLetters(idx))
-						spreadsheetHeaders = append(spreadsheetHeaders, fmt.Sprintf("%s [%s]", parentData.(string), intToLetters(idx+1)))
-					} else if len(i.(string)) == 0 {
-						columnName := intToLetters(idx + 1) // since index in for is zero-based
-						spreadsheetHeaders = append(spreadsheetHeaders, columnName)
-					} else {
-						if slices.Contains(spreadsheetHeaders, i.(string)) {
-							spreadsheetHeaders = append(spreadsheetHeaders, fmt.Sprintf("%s [%s]", i.(string), intToLetters(idx+1)))
-						} else {
-							spreadsheetHeaders = append(spreadsheetHeaders, i.(string))
-						}
-					}
-				}
+				spreadsheetHeaders := getHeaders(data.Values, mergeCellInfo)
+				tables[sheetName] = newDynamicTable(ctx, p, sheetName, spreadsheetHeaders, getSheetRowData(gridData, sheetName))
+			}
+		}
+	}
 
-				/*
-					* Case:
-					  | Col A |       |       |
-					  | ----- | ----- | ----- |
-					  | val A | val B | val C |
-					* Expected output
-					  | Col A | B     | C     |
-					  | ----- | ----- | ----- |
-					  | val A | val B | val C |
-				*/
-				if len(data.Values[0]) < maxColsLength {
-					for i := len(data.Values[0]); i < maxColsLength; i++ {
-						columnName := intToLetters(i + 1)
-						spreadsheetHeaders = append(spreadsheetHeaders, columnName)
-					}
-				}
-				googleSpreadsheetHeadersMap[sheetName] = spreadsheetHeaders
+	// Create tablemap for all the valid named ranges
+	// If no named ranges are specified in the config arg, no dynamic tables will be created for them
+	if len(googleSheetsConfig.NamedRanges) > 0 {
+		addNamedRangeTables(ctx, p, googleSheetsConfig.NamedRanges, tables)
+	}
 
-				// Create columns
-				cols := []*plugin.Column{}
-				for idx, j := range spreadsheetHeaders {
-					cols = append(cols, &plugin.Column{Name: j, Type: proto.ColumnType_STRING, Transform: transform.FromField(j), Description: fmt.Sprintf("Field %d.", idx)})
+	// Create tablemap for all the valid tables, whose columns are defined by the table itself
+	// If no tables are specified in the config arg, no dynamic tables will be created for them
+	if len(googleSheetsConfig.Tables) > 0 {
+		sheetTables, err := getSpreadsheetTables(ctx, p)
+		if err != nil {
+			return tables, nil
+		}
+		for _, pattern := range googleSheetsConfig.Tables {
+			for _, sheetTable := range sheetTables {
+				// Sheets and named ranges take precedence over tables with the same name
+				if _, ok := tables[sheetTable.Table.Name]; ok {
+					continue
 				}
-
-				// Create table definition
-				tables[sheetName] = &plugin.Table{
-					Name:        sheetName,
-					Description: fmt.Sprintf("Retrieves data from %s.", sheetName),
-					List: &plugin.ListConfig{
-						Hydrate: listSpreadsheetWithPath(ctx, p, sheetName),
-					},
-					Columns: cols,
+				if ok, _ := path.Match(pattern, sheetTable.Table.Name); ok {
+					tables[sheetTable.Table.Name] = newSheetTable(ctx, p, sheetTable.Table)
 				}
 			}
 		}
@@ -158,3 +153,180 @@ func PluginTables(ctx context.Context, p *plugin.TableMapData) (map[string]*plug
 
 	return tables, nil
 }
+
+// Returns true if the first cell of the given values is not empty, so that it can be used as a header row
+func hasHeaderRow(values [][]interface{}) bool {
+	// Return if empty sheet
+	if len(values) == 0 {
+		return false
+	}
+
+	// Return if first row is empty
+	if len(values[0]) == 0 {
+		return false
+	}
+
+	// Return if A1 cell is empty
+	if len(values[0][0].(string)) == 0 {
+		return false
+	}
+
+	return true
+}
+
+// Returns the column names of a dynamic table, using the first row of the given values as the header
+// Merges must be relative to the given values, i.e. the first value is at row 1, column 1
+func getHeaders(values [][]interface{}, mergeCellInfo []*sheets.GridRange) []string {
+	var spreadsheetHeaders []string
+	maxColsLength := getMaxLength(values)
+	for idx, i := range values[0] {
+		mergeRow, mergeColumn, _, parentColumn := findMergeCells(mergeCellInfo, int64(1), int64(idx+1))
+		if mergeRow != nil && mergeColumn != nil && *parentColumn >= 1 && len(spreadsheetHeaders) > 0 { // Merge cell
+			parentData := values[0][*parentColumn-1]
+			spreadsheetHeaders[len(spreadsheetHeaders)-1] = fmt.Sprintf("%s [%s]", spreadsheetHeaders[len(spreadsheetHeaders)-1], intToLetters(idx))
+			spreadsheetHeaders = append(spreadsheetHeaders, fmt.Sprintf("%s [%s]", parentData.(string), intToLetters(idx+1)))
+		} else if len(i.(string)) == 0 {
+			columnName := intToLetters(idx + 1) // since index in for is zero-based
+			spreadsheetHeaders = append(spreadsheetHeaders, columnName)
+		} else {
+			if slices.Contains(spreadsheetHeaders, i.(string)) {
+				spreadsheetHeaders = append(spreadsheetHeaders, fmt.Sprintf("%s [%s]", i.(string), intToLetters(idx+1)))
+			} else {
+				spreadsheetHeaders = append(spreadsheetHeaders, i.(string))
+			}
+		}
+	}
+
+	/*
+		* Case:
+		  | Col A |       |       |
+		  | ----- | ----- | ----- |
+		  | val A | val B | val C |
+		* Expected output
+		  | Col A | B     | C     |
+		  | ----- | ----- | ----- |
+		  | val A | val B | val C |
+	*/
+	if len(values[0]) < maxColsLength {
+		for i := len(values[0]); i < maxColsLength; i++ {
+			columnName := intToLetters(i + 1)
+			spreadsheetHeaders = append(spreadsheetHeaders, columnName)
+		}
+	}
+
+	return spreadsheetHeaders
+}
+
+// Creates the definition of a dynamic table, along with the headers and column types used to list its rows
+// The column types are inferred from the given cells, where the first row is the header
+func newDynamicTable(ctx context.Context, p *plugin.TableMapData, tableName string, spreadsheetHeaders []string, rowData []*sheets.RowData) *plugin.Table {
+	googleSpreadsheetHeadersMap[tableName] = spreadsheetHeaders
+
+	// Columns of checkboxes are returned as booleans, columns holding dates as timestamps, everything else as text
+	columnTypes := inferColumnTypes(rowData, len(spreadsheetHeaders))
+	googleSpreadsheetColumnTypesMap[tableName] = columnTypes
+
+	return getDynamicTableDefinition(tableName, spreadsheetHeaders, columnTypes, GetConfig(p.Connection).RowIdMetadataKey != nil, listSpreadsheetWithPath(ctx, p, tableName))
+}
+
+// Creates the definition of a dynamic table for a table of a sheet, whose columns and their types are read from the table definition
+func newSheetTable(ctx context.Context, p *plugin.TableMapData, table *sheets.Table) *plugin.Table {
+	// Columns without properties are named after their index, as for missing header values in sheets
+	columnCount := table.Range.EndColumnIndex - table.Range.StartColumnIndex
+	spreadsheetHeaders := make([]string, columnCount)
+	columnTypes := make([]proto.ColumnType, columnCount)
+	for idx := range spreadsheetHeaders {
+		spreadsheetHeaders[idx] = intToLetters(idx + 1)
+		columnTypes[idx] = proto.ColumnType_STRING
+	}
+	for _, column := range table.ColumnProperties {
+		if column.ColumnIndex < 0 || column.ColumnIndex >= columnCount || column.ColumnName == "" {
+			continue
+		}
+		spreadsheetHeaders[column.ColumnIndex] = column.ColumnName
+		columnTypes[column.ColumnIndex] = getTableColumnType(column.ColumnType)
+	}
+	googleSpreadsheetHeadersMap[table.Name] = spreadsheetHeaders
+	googleSpreadsheetColumnTypesMap[table.Name] = columnTypes
+
+	return getDynamicTableDefinition(table.Name, spreadsheetHeaders, columnTypes, GetConfig(p.Connection).RowIdMetadataKey != nil, listSheetTableWithPath(ctx, p, table.Name))
+}
+
+func getDynamicTableDefinition(tableName string, spreadsheetHeaders []string, columnTypes []proto.ColumnType, hasRowID bool, listFunc plugin.HydrateFunc) *plugin.Table {
+	// Create columns
+	cols := []*plugin.Column{}
+	for idx, j := range spreadsheetHeaders {
+		cols = append(cols, &plugin.Column{Name: j, Type: columnTypes[idx], Transform: transform.FromField(j), Description: fmt.Sprintf("Field %d.", idx)})
+	}
+
+	// Add metadata columns, unless a header has the same name
+	if !slices.Contains(spreadsheetHeaders, rowNumberColumn) {
+		cols = append(cols, &plugin.Column{Name: rowNumberColumn, Type: proto.ColumnType_INT, Transform: transform.FromField(rowNumberColumn), Description: "The number of the row in the sheet."})
+	}
+	if !slices.Contains(spreadsheetHeaders, rowHashColumn) {
+		cols = append(cols, &plugin.Column{Name: rowHashColumn, Type: proto.ColumnType_STRING, Transform: transform.FromField(rowHashColumn), Description: "A SHA-256 hash of the formatted values of the row, in column order."})
+	}
+	if hasRowID && !slices.Contains(spreadsheetHeaders, rowIDColumn) {
+		cols = append(cols, &plugin.Column{Name: rowIDColumn, Type: proto.ColumnType_STRING, Transform: transform.FromField(rowIDColumn), Description: "The ID of the row, read from its developer metadata."})
+	}
+
+	// Create table definition
+	return &plugin.Table{
+		Name:        tableName,
+		Description: fmt.Sprintf("Retrieves data from %s.", tableName),
+		List: &plugin.ListConfig{
+			Hydrate: listFunc,
+		},
+		Columns: cols,
+	}
+}
+
+// Adds a dynamic table for each named range matching the given patterns, where the first row of the range is the header
+func addNamedRangeTables(ctx context.Context, p *plugin.TableMapData, patterns []string, tables map[string]*plugin.Table) {
+	availableNamedRanges, err := getSpreadsheetNamedRanges(ctx, p)
+	if err != nil {
+		return
+	}
+	var validNamedRanges []string
+	for _, pattern := range patterns {
+		for _, namedRange := range availableNamedRanges {
+			if ok, _ := path.Match(pattern, namedRange.Name); ok && !slices.Contains(validNamedRanges, namedRange.Name) {
+				validNamedRanges = append(validNamedRanges, namedRange.Name)
+			}
+		}
+	}
+	if len(validNamedRanges) == 0 {
+		return
+	}
+
+	// Value ranges are returned in the same order as the requested named ranges
+	namedRangeData, err := getSpreadsheetHeaders(ctx, p, validNamedRanges)
+	if err != nil {
+		return
+	}
+
+	for idx, namedRange := range validNamedRanges {
+		// Sheets take precedence over named ranges with the same name
+		if _, ok := tables[namedRange]; ok {
+			continue
+		}
+		if idx >= len(namedRangeData) || !hasHeaderRow(namedRangeData[idx].Values) {
+			continue
+		}
+
+		// Without the cell data, the table is still created, with all of its columns typed as STRING
+		var merges []*sheets.GridRange
+		var rowData []*sheets.RowData
+		gridData, err := getSpreadsheetTypeData(ctx, p, []string{namedRange})
+		if err != nil {
+			plugin.Logger(ctx).Warn("addNamedRangeTables", "named_range", namedRange, "column_types_error", err)
+		} else if len(gridData.Sheets) > 0 && len(gridData.Sheets[0].Data) > 0 {
+			// Merges are defined on the whole sheet, whereas the header is the first row of the named range
+			merges = getRelativeMerges(gridData.Sheets[0].Merges, gridData.Sheets[0].Data[0])
+			rowData = gridData.Sheets[0].Data[0].RowData
+		}
+
+		spreadsheetHeaders := getHeaders(namedRangeData[idx].Values, merges)
+		tables[namedRange] = newDynamicTable(ctx, p, namedRange, spreadsheetHeaders, rowData)
+	}
+}
@@ -0,0 +1,414 @@
+package googlesheets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+	htransport "google.golang.org/api/transport/http"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const xlsxMimeType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Error types of the Sheets API, keyed by the error values of XLSX files
+var xlsxErrorTypes = map[string]string{
+	"#NULL!":  "NULL_VALUE",
+	"#DIV/0!": "DIVIDE_BY_ZERO",
+	"#VALUE!": "VALUE",
+	"#REF!":   "REF",
+	"#NAME?":  "NAME",
+	"#NUM!":   "NUM",
+	"#N/A":    "N_A",
+}
+
+// getExportedSpreadsheet returns the cells of a spreadsheet at a given revision, read from its XLSX export
+// The current version of the spreadsheet is exported if no revision is given
+// Revisions never change, so they are cached for the lifetime of the connection cache
+func getExportedSpreadsheet(ctx context.Context, d *plugin.QueryData, spreadsheetID string, revisionID string) (*sheets.Spreadsheet, error) {
+	cacheKey := fmt.Sprintf("googlesheets.revision.%s.%s", spreadsheetID, revisionID)
+	if revisionID != "" {
+		if data, ok := d.ConnectionCache.Get(ctx, cacheKey); ok {
+			return data.(*sheets.Spreadsheet), nil
+		}
+	}
+
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getExportedSpreadsheet", "connection_error", err)
+		return nil, err
+	}
+
+	// Google Sheets files can't be downloaded directly, only through their export links
+	var exportLinks map[string]string
+	if revisionID != "" {
+		revision, err := svc.Revisions.Get(spreadsheetID, revisionID).Fields("exportLinks").Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		exportLinks = revision.ExportLinks
+	} else {
+		file, err := svc.Files.Get(spreadsheetID).SupportsAllDrives(true).Fields("exportLinks").Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		exportLinks = file.ExportLinks
+	}
+	exportLink, ok := exportLinks[xlsxMimeType]
+	if !ok {
+		return nil, fmt.Errorf("spreadsheet %s can't be exported as XLSX at revision %q", spreadsheetID, revisionID)
+	}
+
+	// The export link requires the same credentials as the API
+	client, _, err := htransport.NewClient(ctx, append(opts, option.WithScopes(drive.DriveReadonlyScope))...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getExportedSpreadsheet", "connection_error", err)
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, exportLink, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to export spreadsheet %s at revision %q: %s", spreadsheetID, revisionID, resp.Status)
+	}
+
+	file, err := excelize.OpenReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := xlsxToSpreadsheet(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if revisionID != "" {
+		if err := d.ConnectionCache.Set(ctx, cacheKey, data); err != nil {
+			plugin.Logger(ctx).Error("getExportedSpreadsheet", "cache_set_error", err)
+		}
+	}
+
+	return data, nil
+}
+
+// xlsxToSpreadsheet converts a XLSX file into the grid data returned by the Sheets API, so that its cells can be read the same way
+// Only the values, formulas, notes, hyperlinks, date formats and merges of the cells are converted
+func xlsxToSpreadsheet(file *excelize.File) (*sheets.Spreadsheet, error) {
+	data := &sheets.Spreadsheet{}
+	for _, sheetName := range file.GetSheetList() {
+		formattedRows, err := file.GetRows(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		rawRows, err := file.GetRows(sheetName, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+
+		notes := map[string]string{}
+		comments, err := file.GetComments(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			notes[comment.Cell] = comment.Text
+		}
+
+		sheet := &sheets.Sheet{Properties: &sheets.SheetProperties{Title: sheetName}}
+		gridData := &sheets.GridData{}
+		for rowCount, row := range rawRows {
+			rowData := &sheets.RowData{}
+			for colCount, raw := range row {
+				cellName, err := excelize.CoordinatesToCellName(colCount+1, rowCount+1)
+				if err != nil {
+					return nil, err
+				}
+				var formatted string
+				if rowCount < len(formattedRows) && colCount < len(formattedRows[rowCount]) {
+					formatted = formattedRows[rowCount][colCount]
+				}
+				cell, err := xlsxToCellData(file, sheetName, cellName, raw, formatted)
+				if err != nil {
+					return nil, err
+				}
+				cell.Note = notes[cellName]
+				rowData.Values = append(rowData.Values, cell)
+			}
+			gridData.RowData = append(gridData.RowData, rowData)
+		}
+		sheet.Data = []*sheets.GridData{gridData}
+
+		mergeCells, err := file.GetMergeCells(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		for _, mergeCell := range mergeCells {
+			startCol, startRow, err := excelize.CellNameToCoordinates(mergeCell.GetStartAxis())
+			if err != nil {
+				return nil, err
+			}
+			endCol, endRow, err := excelize.CellNameToCoordinates(mergeCell.GetEndAxis())
+			if err != nil {
+				return nil, err
+			}
+			sheet.Merges = append(sheet.Merges, &sheets.GridRange{
+				StartRowIndex:    int64(startRow - 1),
+				EndRowIndex:      int64(endRow),
+				StartColumnIndex: int64(startCol - 1),
+				EndColumnIndex:   int64(endCol),
+			})
+		}
+
+		data.Sheets = append(data.Sheets, sheet)
+	}
+
+	return data, nil
+}
+
+func xlsxToCellData(file *excelize.File, sheetName string, cellName string, raw string, formatted string) (*sheets.CellData, error) {
+	cell := &sheets.CellData{FormattedValue: formatted}
+
+	formula, err := file.GetCellFormula(sheetName, cellName)
+	if err != nil {
+		return nil, err
+	}
+	if formula != "" {
+		formula = "=" + formula
+		cell.UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &formula}
+	}
+	if raw == "" {
+		return cell, nil
+	}
+
+	cellType, err := file.GetCellType(sheetName, cellName)
+	if err != nil {
+		return nil, err
+	}
+	value := &sheets.ExtendedValue{}
+	switch cellType {
+	case excelize.CellTypeBool:
+		boolValue := raw == "1" || strings.EqualFold(raw, "true")
+		value.BoolValue = &boolValue
+		cell.FormattedValue = strings.ToUpper(strconv.FormatBool(boolValue))
+	case excelize.CellTypeError:
+		value.ErrorValue = &sheets.ErrorValue{Type: xlsxErrorTypes[raw], Message: raw}
+		if value.ErrorValue.Type == "" {
+			value.ErrorValue.Type = "ERROR"
+		}
+	case excelize.CellTypeInlineString, excelize.CellTypeSharedString, excelize.CellTypeFormula:
+		value.StringValue = &raw
+	default:
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			value.NumberValue = &number
+		} else {
+			value.StringValue = &raw
+		}
+	}
+	cell.EffectiveValue = value
+
+	// Dates are stored as numbers, and only identified by their number format
+	if value.NumberValue != nil {
+		numberFormatType, err := getXLSXNumberFormatType(file, sheetName, cellName)
+		if err != nil {
+			return nil, err
+		}
+		if numberFormatType != "" {
+			cell.EffectiveFormat = &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: numberFormatType}}
+		}
+	}
+
+	_, hyperlink, err := file.GetCellHyperLink(sheetName, cellName)
+	if err != nil {
+		return nil, err
+	}
+	cell.Hyperlink = hyperlink
+
+	return cell, nil
+}
+
+// getXLSXNumberFormatType returns the type of the number format of a cell, as returned by the Sheets API
+// Only date, time and percent formats are identified
+func getXLSXNumberFormatType(file *excelize.File, sheetName string, cellName string) (string, error) {
+	styleID, err := file.GetCellStyle(sheetName, cellName)
+	if err != nil {
+		return "", err
+	}
+	style, err := file.GetStyle(styleID)
+	if err != nil {
+		return "", err
+	}
+
+	if style.CustomNumFmt != nil {
+		// Ignore the quoted text and colors of the format, e.g. "Due: "dd/mm or [Red]0.00
+		var format strings.Builder
+		inQuotes, inBrackets := false, false
+		for _, r := range strings.ToLower(*style.CustomNumFmt) {
+			switch {
+			case r == '"':
+				inQuotes = !inQuotes
+			case r == '[' && !inQuotes:
+				inBrackets = true
+			case r == ']' && !inQuotes:
+				inBrackets = false
+			case !inQuotes && !inBrackets:
+				format.WriteRune(r)
+			}
+		}
+		hasDate := strings.ContainsAny(format.String(), "yd")
+		hasTime := strings.ContainsAny(format.String(), "hs")
+		switch {
+		case hasDate && hasTime:
+			return numberFormatDateTime, nil
+		case hasDate:
+			return numberFormatDate, nil
+		case hasTime:
+			return "TIME", nil
+		case strings.Contains(format.String(), "%"):
+			return "PERCENT", nil
+		}
+		return "", nil
+	}
+
+	// Built-in number formats of the OOXML specification
+	switch style.NumFmt {
+	case 14, 15, 16, 17:
+		return numberFormatDate, nil
+	case 22:
+		return numberFormatDateTime, nil
+	case 18, 19, 20, 21, 45, 46, 47:
+		return "TIME", nil
+	case 9, 10:
+		return "PERCENT", nil
+	}
+	return "", nil
+}
+
+// getRevisionCells returns the cells of a revision of a spreadsheet, filtered by the given ranges in A1 notation, and by the given sheet names
+// Sheet names are matched as they are, and are never parsed as ranges, e.g. a sheet named Q1 is not the cell Q1
+func getRevisionCells(ctx context.Context, d *plugin.QueryData, spreadsheetID string, revisionID string, ranges []string, sheetNames []string) ([]cellInfo, error) {
+	data, err := getExportedSpreadsheet(ctx, d, spreadsheetID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	type cellRange struct {
+		sheetName string
+		gridRange *sheets.GridRange
+	}
+	var cellRanges []cellRange
+	for _, r := range ranges {
+		sheetName, gridRange, err := resolveRevisionRange(data, r)
+		if err != nil {
+			return nil, err
+		}
+		cellRanges = append(cellRanges, cellRange{sheetName: sheetName, gridRange: gridRange})
+	}
+	for _, sheetName := range sheetNames {
+		cellRanges = append(cellRanges, cellRange{sheetName: sheetName})
+	}
+
+	// XLSX files have no time zone, so dates are interpreted using the current time zone of the spreadsheet
+	settings, err := getSpreadsheetSettings(ctx, d, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cells []cellInfo
+	iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+		if len(cellRanges) > 0 {
+			inRange := false
+			for _, r := range cellRanges {
+				if r.sheetName == sheetName && isInGridRange(r.gridRange, int64(rowCount), int64(colCount)) {
+					inRange = true
+					break
+				}
+			}
+			if !inRange {
+				return true
+			}
+		}
+		cells = append(cells, getCellInfo(sheetName, rowCount, colCount, cell, settings))
+		return true
+	})
+
+	return cells, nil
+}
+
+// resolveRevisionRange returns the sheet name and the indexes of a range of an exported spreadsheet
+// As in the Sheets API, a range matching the name of a sheet refers to the whole sheet, e.g. Sheet1 or FY2024, even if it looks like a cell
+// Other ranges are parsed in A1 notation, where ranges without a sheet name refer to the first sheet
+func resolveRevisionRange(data *sheets.Spreadsheet, r string) (string, *sheets.GridRange, error) {
+	for _, sheet := range data.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == r {
+			return r, nil, nil
+		}
+	}
+
+	sheetName, gridRange, err := parseA1Notation(r)
+	if err != nil {
+		return "", nil, err
+	}
+	if sheetName == "" && len(data.Sheets) > 0 {
+		sheetName = data.Sheets[0].Properties.Title
+	}
+	return sheetName, gridRange, nil
+}
+
+// getSpreadsheetSettings returns the time zone and locale settings of a spreadsheet
+func getSpreadsheetSettings(ctx context.Context, d *plugin.QueryData, spreadsheetID string) (*spreadsheetSettings, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getSpreadsheetSettings", "connection_error", err)
+		return nil, err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("properties(timeZone,locale)")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpreadsheetSettings(resp.Properties), nil
+}
+
+// Returns true if the given zero-based row and column indexes are in the range, where an end index of 0 means unbounded
+func isInGridRange(r *sheets.GridRange, row int64, col int64) bool {
+	if r == nil {
+		return true
+	}
+	if row < r.StartRowIndex || (r.EndRowIndex != 0 && row >= r.EndRowIndex) {
+		return false
+	}
+	if col < r.StartColumnIndex || (r.EndColumnIndex != 0 && col >= r.EndColumnIndex) {
+		return false
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package googlesheets
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -70,6 +71,61 @@ func getSpreadsheets(ctx context.Context, d *plugin.TableMapData) ([]string, err
 	return spreadsheetList, nil
 }
 
+// Returns all the named ranges in a given spreadsheet
+func getSpreadsheetNamedRanges(ctx context.Context, d *plugin.TableMapData) ([]namedRangeInfo, error) {
+	// To get config arguments from plugin config file
+	opts, err := getSessionConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getSpreadsheetNamedRanges", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetID(ctx, d)
+
+	return getNamedRanges(ctx, svc, spreadsheetID)
+}
+
+// Returns all the tables in a given spreadsheet, along with the name of the sheet they are defined on
+func getSpreadsheetTables(ctx context.Context, d *plugin.TableMapData) ([]sheetTableInfo, error) {
+	// To get config arguments from plugin config file
+	opts, err := getSessionConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getSpreadsheetTables", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetID(ctx, d)
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties.title,tables)")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []sheetTableInfo
+	for _, sheet := range resp.Sheets {
+		for _, table := range sheet.Tables {
+			if table.Range == nil {
+				continue
+			}
+			tables = append(tables, sheetTableInfo{SheetName: sheet.Properties.Title, Table: table})
+		}
+	}
+
+	return tables, nil
+}
+
 // Returns all the merge cells in a given sheet
 func getMergeCells(ctx context.Context, d *plugin.TableMapData, sheetName string) ([]*sheets.GridRange, error) {
 	// To get config arguments from plugin config file
@@ -99,8 +155,24 @@ func getMergeCells(ctx context.Context, d *plugin.TableMapData, sheetName string
 	return nil, nil
 }
 
-// Returns all the cells of a sheet in given spreadsheet
-func getSpreadsheetData(ctx context.Context, d *plugin.TableMapData, sheetNames []string) ([]*sheets.Sheet, error) {
+// Returns all the cells of the given sheets or named ranges in given spreadsheet, along with the spreadsheet's time zone and locale
+func getSpreadsheetData(ctx context.Context, d *plugin.TableMapData, sheetNames []string) (*sheets.Spreadsheet, error) {
+	// The developer metadata of the rows is only fetched if it is used to identify them
+	dataFields := "rowData(values(formattedValue,effectiveValue,effectiveFormat/numberFormat,chipRuns,dataValidation)),startColumn,startRow"
+	if GetConfig(d.Connection).RowIdMetadataKey != nil {
+		dataFields += ",rowMetadata(developerMetadata(metadataKey,metadataValue))"
+	}
+
+	return getSpreadsheetGridData(ctx, d, sheetNames, fmt.Sprintf("properties(timeZone,locale),sheets(properties.title,data(%s),merges)", dataFields))
+}
+
+// Returns the cells of the given sheets or named ranges in given spreadsheet, with only the fields required to infer the types of their columns
+// Used when the schema is loaded, where smart chips and row metadata are not needed
+func getSpreadsheetTypeData(ctx context.Context, d *plugin.TableMapData, sheetNames []string) (*sheets.Spreadsheet, error) {
+	return getSpreadsheetGridData(ctx, d, sheetNames, "sheets(properties.title,data(rowData(values(formattedValue,effectiveValue,effectiveFormat/numberFormat,dataValidation)),startColumn,startRow),merges)")
+}
+
+func getSpreadsheetGridData(ctx context.Context, d *plugin.TableMapData, sheetNames []string, fields string) (*sheets.Spreadsheet, error) {
 	// To get config arguments from plugin config file
 	opts, err := getSessionConfig(ctx, d)
 	if err != nil {
@@ -110,13 +182,13 @@ func getSpreadsheetData(ctx context.Context, d *plugin.TableMapData, sheetNames
 	// Create service
 	svc, err := sheets.NewService(ctx, opts...)
 	if err != nil {
-		plugin.Logger(ctx).Error("getSpreadsheetData", "connection_error", err)
+		plugin.Logger(ctx).Error("getSpreadsheetGridData", "connection_error", err)
 		return nil, err
 	}
 
 	spreadsheetID := getSpreadsheetID(ctx, d)
 
-	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("sheets(properties.title,data(rowData(values(formattedValue))),merges)"))
+	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field(fields))
 	if len(sheetNames) > 0 {
 		resp.Ranges(sheetNames...)
 	}
@@ -125,7 +197,7 @@ func getSpreadsheetData(ctx context.Context, d *plugin.TableMapData, sheetNames
 		return nil, err
 	}
 
-	return data.Sheets, nil
+	return data, nil
 }
 
 func getSessionConfig(ctx context.Context, d *plugin.TableMapData) ([]option.ClientOption, error) {
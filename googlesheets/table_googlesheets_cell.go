@@ -2,7 +2,11 @@ package googlesheets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/sheets/v4"
@@ -13,16 +17,35 @@ import (
 )
 
 type cellInfo = struct {
-	Column    string
-	Row       int
-	Cell      string
-	Value     string
-	Formula   string
-	Note      string
-	Hyperlink string
-	SheetName string
+	Column           string
+	Row              int
+	Cell             string
+	Value            string
+	ValueType        string
+	NumberValue      *float64
+	BoolValue        *bool
+	StringValue      *string
+	ErrorType        string
+	ErrorMessage     string
+	NumberFormatType string
+	TimestampValue   *time.Time
+	Formula          string
+	Note             string
+	Hyperlink        string
+	EffectiveFormat  *sheets.CellFormat
+	TextFormatRuns   []*sheets.TextFormatRun
+	Chips            []cellChip
+	FirstChip        *cellChip
+	DataValidation   *sheets.DataValidationRule
+	SheetName        string
 }
 
+// Columns which require the `chipRuns` of the cells
+var cellChipColumns = []string{"chips", "chip_type", "chip_person_email", "chip_uri", "chip_display_text"}
+
+// Columns which require the full `effectiveFormat` of the cells
+var cellFormatColumns = []string{"effective_format", "background_color", "text_format", "horizontal_alignment", "wrap_strategy", "borders"}
+
 //// TABLE DEFINITION
 
 func tableGoogleSheetsCell(_ context.Context) *plugin.Table {
@@ -52,6 +75,18 @@ func tableGoogleSheetsCell(_ context.Context) *plugin.Table {
 					Name:    "row",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "revision_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "developer_metadata_key",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "developer_metadata_value",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Columns: []*plugin.Column{
@@ -81,6 +116,50 @@ func tableGoogleSheetsCell(_ context.Context) *plugin.Table {
 				Description: "The value of a cell.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "value_type",
+				Description: "The type of the value of a cell. Possible values are: NUMBER, STRING, BOOL and ERROR.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_value",
+				Description: "The value of a cell, if it holds a number. Dates, times and date-times are returned as serial numbers.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("NumberValue"),
+			},
+			{
+				Name:        "bool_value",
+				Description: "The value of a cell, if it holds a boolean.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("BoolValue"),
+			},
+			{
+				Name:        "string_value",
+				Description: "The value of a cell, if it holds a string.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StringValue"),
+			},
+			{
+				Name:        "error_type",
+				Description: "The type of error of a cell, if its value is an error, e.g. DIVIDE_BY_ZERO, REF or N_A.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "error_message",
+				Description: "A message with more information about the error of a cell, in the spreadsheet's locale.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_format_type",
+				Description: "The type of the number format applied to a cell, e.g. NUMBER, CURRENCY, DATE or TEXT.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timestamp_value",
+				Description: "The value of a date or date-time formatted cell as a timestamp, interpreted in the time zone of the spreadsheet.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("TimestampValue"),
+			},
 			{
 				Name:        "formula",
 				Description: "The formula configured for a cell.",
@@ -93,15 +172,107 @@ func tableGoogleSheetsCell(_ context.Context) *plugin.Table {
 			},
 			{
 				Name:        "hyperlink",
-				Description: "A hyperlink this cell points to, if any. If the cell contains multiple hyperlinks, this field will be empty.",
+				Description: "A hyperlink this cell points to, if any. If the cell contains multiple hyperlinks, this field will be empty, and the links can be queried using the googlesheets_cell_link table.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "text_format_runs",
+				Description: "The runs of rich text applied to subsections of a cell, each with its start index, format and link URI, if any.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "chips",
+				Description: "The smart chips (people, files and places) in a cell, each with its type, person email or URI, and display text.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "chip_type",
+				Description: "The type of the first smart chip in a cell. Possible values are: PERSON and RICH_LINK (files and places).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FirstChip.Type"),
+			},
+			{
+				Name:        "chip_person_email",
+				Description: "The email address of the first smart chip in a cell, if it is a person chip.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FirstChip.PersonEmail"),
+			},
+			{
+				Name:        "chip_uri",
+				Description: "The URI of the first smart chip in a cell, if it is a rich link chip (e.g. a file or place).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FirstChip.Uri"),
+			},
+			{
+				Name:        "chip_display_text",
+				Description: "The text displayed for the first smart chip in a cell.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FirstChip.DisplayText"),
+			},
+			{
+				Name:        "data_validation",
+				Description: "The data validation rule of a cell, if any.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "effective_format",
+				Description: "The effective format being used by a cell, i.e. the user entered format merged with conditional formatting and the default format.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "background_color",
+				Description: "The background color of a cell, as a hex string (e.g. #ff0000), or the name of the theme color applied to the cell.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EffectiveFormat.BackgroundColorStyle").Transform(colorStyleToString),
+			},
+			{
+				Name:        "text_format",
+				Description: "The text format of a cell, e.g. bold, italic, font family, font size and foreground color.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("EffectiveFormat.TextFormat"),
+			},
+			{
+				Name:        "horizontal_alignment",
+				Description: "The horizontal alignment of the value in a cell. Possible values are: LEFT, CENTER and RIGHT.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EffectiveFormat.HorizontalAlignment"),
+			},
+			{
+				Name:        "wrap_strategy",
+				Description: "The wrap strategy for the value in a cell. Possible values are: OVERFLOW_CELL, LEGACY_WRAP, CLIP and WRAP.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EffectiveFormat.WrapStrategy"),
+			},
+			{
+				Name:        "borders",
+				Description: "The borders of a cell.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("EffectiveFormat.Borders"),
+			},
 			{
 				Name:        "range",
 				Description: "The ranges to retrieve from the spreadsheet.",
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromQual("range"),
 			},
+			{
+				Name:        "revision_id",
+				Description: "The ID of the revision the cells are read from. If not set, the cells of the current version of the spreadsheet are returned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("revision_id"),
+			},
+			{
+				Name:        "developer_metadata_key",
+				Description: "The key of the developer metadata the cells are tagged with. Only the cells of the rows, columns and sheets associated with matching developer metadata are returned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("developer_metadata_key"),
+			},
+			{
+				Name:        "developer_metadata_value",
+				Description: "The value of the developer metadata the cells are tagged with.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("developer_metadata_value"),
+			},
 			{
 				Name:        "spreadsheet_id",
 				Description: "The ID of the spreadsheet.",
@@ -132,11 +303,144 @@ func listGoogleSheetCells(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	// Get the ID of the spreadsheet
 	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
 
-	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("sheets(properties.title,data(rowData,startColumn,startRow),merges)"))
+	// Past revisions aren't available in the Sheets API, so they are read from their XLSX export instead
+	if d.EqualsQuals["revision_id"] != nil {
+		// Developer metadata isn't included in the XLSX export
+		if d.EqualsQuals["developer_metadata_key"] != nil || d.EqualsQuals["developer_metadata_value"] != nil {
+			return nil, errors.New("revision_id can't be used along with developer_metadata_key or developer_metadata_value")
+		}
+		// A `sheet_name` qual on its own is a sheet name, whereas the other quals are ranges
+		var ranges, sheetNames []string
+		if d.EqualsQuals["range"] != nil || d.EqualsQuals["cell"] != nil || d.EqualsQuals["row"] != nil || d.EqualsQuals["col"] != nil {
+			ranges = getCellRanges(d.EqualsQuals)
+		} else {
+			sheetNames = getQualListValues(d.EqualsQuals)
+		}
+		cells, err := getRevisionCells(ctx, d, spreadsheetID, d.EqualsQualString("revision_id"), ranges, sheetNames)
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range cells {
+			// Ranges only narrow down the cells when the sheet name is known
+			if !matchesCellQuals(d.EqualsQuals, cell.SheetName, cell.Row-1, lettersToInt(cell.Column)-1) {
+				continue
+			}
+
+			d.StreamListItem(ctx, cell)
 
-	// Additional filters
-	quals := d.EqualsQuals
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
 
+	// The formatting of the cells is only fetched if any of the format columns are requested, since it largely increases the size of the response
+	cellFields := []string{"formattedValue", "effectiveValue", "userEnteredValue", "note", "hyperlink"}
+	if isColumnRequested(d, cellFormatColumns...) {
+		cellFields = append(cellFields, "effectiveFormat")
+	} else {
+		cellFields = append(cellFields, "effectiveFormat/numberFormat")
+	}
+	if isColumnRequested(d, "text_format_runs") {
+		cellFields = append(cellFields, "textFormatRuns")
+	}
+	if isColumnRequested(d, cellChipColumns...) {
+		cellFields = append(cellFields, "chipRuns")
+	}
+	if isColumnRequested(d, "data_validation") {
+		cellFields = append(cellFields, "dataValidation")
+	}
+
+	fields := googleapi.Field(fmt.Sprintf("properties(timeZone,locale),sheets(properties.title,data(rowData(values(%s)),startColumn,startRow),merges)", strings.Join(cellFields, ",")))
+
+	var data *sheets.Spreadsheet
+	if d.EqualsQuals["developer_metadata_key"] != nil || d.EqualsQuals["developer_metadata_value"] != nil {
+		// Data filters are combined with OR by the API, so they can't be used to narrow down a range
+		if d.EqualsQuals["range"] != nil {
+			return nil, errors.New("range can't be used along with developer_metadata_key or developer_metadata_value")
+		}
+
+		// The cells are looked up by the developer metadata of their rows, columns or sheets, wherever these currently are
+		// Other quals, e.g. `sheet_name` or `row`, are applied to the returned cells below
+		req := &sheets.GetSpreadsheetByDataFilterRequest{
+			DataFilters: []*sheets.DataFilter{
+				{
+					DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{
+						MetadataKey:   d.EqualsQualString("developer_metadata_key"),
+						MetadataValue: d.EqualsQualString("developer_metadata_value"),
+					},
+				},
+			},
+			IncludeGridData: true,
+		}
+		data, err = svc.Spreadsheets.GetByDataFilter(spreadsheetID, req).Fields(fields).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(fields)
+
+		// Additional filters
+		if ranges := getCellRanges(d.EqualsQuals); len(ranges) > 0 {
+			resp.Ranges(ranges...)
+		}
+
+		data, err = resp.Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Dates are interpreted using the spreadsheet's time zone and locale
+	settings := newSpreadsheetSettings(data.Properties)
+
+	iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+		// Ranges are applied by the API, except for the cells looked up by developer metadata
+		if !matchesCellQuals(d.EqualsQuals, sheetName, rowCount, colCount) {
+			return true
+		}
+
+		d.StreamListItem(ctx, getCellInfo(sheetName, rowCount, colCount, cell, settings))
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+
+	return nil, nil
+}
+
+// matchesCellQuals returns true if a cell matches the `sheet_name`, `cell`, `row` and `col` quals of the query
+func matchesCellQuals(quals plugin.KeyColumnEqualsQualMap, sheetName string, rowCount int, colCount int) bool {
+	if q := quals["sheet_name"]; q != nil {
+		if list := q.GetListValue(); list != nil {
+			if !slices.ContainsFunc(list.Values, func(value *proto.QualValue) bool { return value.GetStringValue() == sheetName }) {
+				return false
+			}
+		} else if q.GetStringValue() != sheetName {
+			return false
+		}
+	}
+	// The API accepts lower case and absolute references, e.g. `a1` or `$A$1`
+	normalize := func(value string) string {
+		return strings.ToUpper(strings.ReplaceAll(value, "$", ""))
+	}
+	column := intToLetters(colCount + 1)
+	if q := quals["cell"]; q != nil && normalize(q.GetStringValue()) != fmt.Sprintf("%s%d", column, rowCount+1) {
+		return false
+	}
+	if q := quals["row"]; q != nil && q.GetInt64Value() != int64(rowCount+1) {
+		return false
+	}
+	if q := quals["col"]; q != nil && normalize(q.GetStringValue()) != column {
+		return false
+	}
+	return true
+}
+
+// getCellRanges builds the `ranges` filter of the request from the quals of a cell based table
+func getCellRanges(quals plugin.KeyColumnEqualsQualMap) []string {
 	/*
 		 * If `range` qual is defined, API will use that filter directly
 		 * If `sheet_name` qual is defined, the following checks will be performed
@@ -149,36 +453,32 @@ func listGoogleSheetCells(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 
 	// If `range` qual is defined, API will use that filter directly
 	if quals["range"] != nil && quals["range"].GetStringValue() != "" {
-		resp.Ranges(quals["range"].GetStringValue())
+		return []string{quals["range"].GetStringValue()}
 	} else if quals["sheet_name"] != nil {
 		sheetName := quals["sheet_name"].GetStringValue()
 		if quals["cell"] != nil { // only `cell` defined
-			sheetRange := fmt.Sprintf("%s!%s", sheetName, quals["cell"].GetStringValue())
-			resp.Ranges(sheetRange)
+			return []string{fmt.Sprintf("%s!%s", sheetName, quals["cell"].GetStringValue())}
 		} else if quals["row"] != nil && quals["col"] != nil { // both `row` and `col` defined
 			row := quals["row"].GetInt64Value()
 			col := quals["col"].GetStringValue()
-			sheetRange := fmt.Sprintf("%s!%s%d", sheetName, col, row)
-			resp.Ranges(sheetRange)
+			return []string{fmt.Sprintf("%s!%s%d", sheetName, col, row)}
 		} else if quals["row"] != nil { // only `row` defined
 			row := quals["row"].GetInt64Value()
-			sheetRange := fmt.Sprintf("%s!%d:%d", sheetName, row, row)
-			resp.Ranges(sheetRange)
+			return []string{fmt.Sprintf("%s!%d:%d", sheetName, row, row)}
 		} else if quals["col"] != nil { // only `col` defined
 			col := quals["col"].GetStringValue()
-			sheetRange := fmt.Sprintf("%s!%s:%s", sheetName, col, col)
-			resp.Ranges(sheetRange)
-		} else {
-			ranges := getQualListValues(quals)
-			resp.Ranges(ranges...)
+			return []string{fmt.Sprintf("%s!%s:%s", sheetName, col, col)}
 		}
+		return getQualListValues(quals)
 	}
 
-	data, err := resp.Context(ctx).Do()
-	if err != nil {
-		return nil, err
-	}
+	return nil
+}
 
+// iterateCells calls the given function for each non-empty cell of a spreadsheet fetched with grid data
+// Merged cells are resolved to the data of their parent cell
+// Iteration stops as soon as the function returns false
+func iterateCells(data *sheets.Spreadsheet, fn func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool) {
 	/*
 	   * JSON representation of response
 	    {
@@ -197,41 +497,49 @@ func listGoogleSheetCells(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	      ]
 	    }
 	*/
-	if data.Sheets != nil {
-		for _, sheet := range data.Sheets {
-			if sheet.Data != nil {
-				for _, i := range sheet.Data {
-					for rowCount, row := range i.RowData {
-						// If a range has been passed to query a particular range, `StartRow` will indicate the start row index(zero-based)
-						rowCount = rowCount + int(i.StartRow)
-						for colCount, value := range row.Values {
-							var rowInfo cellInfo
-
-							// If a range has been passed to query a particular range, `StartColumn` will indicate the start column index(zero-based)
-							colCount = colCount + int(i.StartColumn)
-							mergeRow, mergeColumn, parentRow, parentColumn := findMergeCells(sheet.Merges, int64(rowCount+1), int64(colCount+1))
-							if mergeRow != nil && mergeColumn != nil { // Merge cell
-								if len(i.RowData) > int(*parentRow) && i.RowData[*parentRow-1] != nil && i.RowData[*parentRow-1].Values[*parentColumn-1] != nil {
-									parentData := i.RowData[*parentRow-1].Values[*parentColumn-1]
-									rowInfo = getCellInfo(sheet.Properties.Title, rowCount, colCount, parentData)
-								}
-							} else if value.UserEnteredValue != nil && value.UserEnteredValue.FormulaValue != nil { // Image in cell
-								rowInfo = getCellInfo(sheet.Properties.Title, rowCount, colCount, value)
-							} else if value.FormattedValue != "" {
-								rowInfo = getCellInfo(sheet.Properties.Title, rowCount, colCount, value)
-							}
-
-							if rowInfo.Value != "" {
-								d.StreamListItem(ctx, rowInfo)
-							}
+	if data == nil || data.Sheets == nil {
+		return
+	}
+	for _, sheet := range data.Sheets {
+		if sheet.Data == nil {
+			continue
+		}
+		for _, i := range sheet.Data {
+			// Merges are relative to the start of the sheet, whereas the row data is relative to the start of the range
+			merges := getRelativeMerges(sheet.Merges, i)
+			for rowIndex, row := range i.RowData {
+				if row == nil {
+					continue
+				}
+				// If a range has been passed to query a particular range, `StartRow` will indicate the start row index(zero-based)
+				rowCount := rowIndex + int(i.StartRow)
+				for colIndex, value := range row.Values {
+					var cell *sheets.CellData
+
+					// If a range has been passed to query a particular range, `StartColumn` will indicate the start column index(zero-based)
+					colCount := colIndex + int(i.StartColumn)
+					mergeRow, mergeColumn, parentRow, parentColumn := findMergeCells(merges, int64(rowIndex+1), int64(colIndex+1))
+					if mergeRow != nil && mergeColumn != nil { // Merge cell
+						// The parent of a merge can be outside of the range
+						if *parentRow >= 1 && int(*parentRow) <= len(i.RowData) && i.RowData[*parentRow-1] != nil && *parentColumn >= 1 && int(*parentColumn) <= len(i.RowData[*parentRow-1].Values) {
+							cell = i.RowData[*parentRow-1].Values[*parentColumn-1]
 						}
+					} else if value.UserEnteredValue != nil && value.UserEnteredValue.FormulaValue != nil { // Image in cell
+						cell = value
+					} else if value.FormattedValue != "" {
+						cell = value
+					}
+
+					if cell == nil || cell.FormattedValue == "" {
+						continue
+					}
+					if !fn(sheet.Properties.Title, rowCount, colCount, cell) {
+						return
 					}
 				}
 			}
 		}
 	}
-
-	return nil, nil
 }
 
 // findMergeCells identifies the merge cells and returns the merge cell along with its parent cell details
@@ -271,21 +579,69 @@ func findMergeCells(mergeInfo []*sheets.GridRange, currentRow int64, currentColu
 	return nil, nil, nil, nil
 }
 
-func getCellInfo(sheetName string, rowCount int, colCount int, data *sheets.CellData) cellInfo {
+func getCellInfo(sheetName string, rowCount int, colCount int, data *sheets.CellData, settings *spreadsheetSettings) cellInfo {
 	var formulaValue string
 	if data.UserEnteredValue != nil && data.UserEnteredValue.FormulaValue != nil {
 		formulaValue = *data.UserEnteredValue.FormulaValue
 	}
+
+	// The typed value of a cell is read from its effective value, i.e. the result of its formula, if any
+	var numberValue *float64
+	var boolValue *bool
+	var stringValue *string
+	var errorType, errorMessage string
+	if data.EffectiveValue != nil {
+		numberValue = data.EffectiveValue.NumberValue
+		boolValue = data.EffectiveValue.BoolValue
+		stringValue = data.EffectiveValue.StringValue
+		if data.EffectiveValue.ErrorValue != nil {
+			errorType = data.EffectiveValue.ErrorValue.Type
+			errorMessage = data.EffectiveValue.ErrorValue.Message
+		}
+	}
+	chips := getCellChips(data)
 	result := cellInfo{
-		SheetName: sheetName,
-		Column:    intToLetters(colCount + 1),
-		Row:       rowCount + 1,
-		Cell:      fmt.Sprintf("%s%d", intToLetters(colCount+1), rowCount+1),
-		Value:     data.FormattedValue,
-		Formula:   formulaValue,
-		Note:      data.Note,
-		Hyperlink: data.Hyperlink,
+		SheetName:        sheetName,
+		Column:           intToLetters(colCount + 1),
+		Row:              rowCount + 1,
+		Cell:             fmt.Sprintf("%s%d", intToLetters(colCount+1), rowCount+1),
+		Value:            data.FormattedValue,
+		ValueType:        getValueType(data),
+		NumberValue:      numberValue,
+		BoolValue:        boolValue,
+		StringValue:      stringValue,
+		ErrorType:        errorType,
+		ErrorMessage:     errorMessage,
+		NumberFormatType: getNumberFormatType(data),
+		TimestampValue:   getCellTimestamp(data, settings),
+		Formula:          formulaValue,
+		Note:             data.Note,
+		Hyperlink:        data.Hyperlink,
+		EffectiveFormat:  data.EffectiveFormat,
+		TextFormatRuns:   data.TextFormatRuns,
+		Chips:            chips,
+		DataValidation:   data.DataValidation,
+	}
+	if len(chips) > 0 {
+		result.FirstChip = &chips[0]
 	}
 
 	return result
 }
+
+//// TRANSFORM FUNCTIONS
+
+// colorStyleToString returns the hex string of an RGB color, or the name of a theme color
+func colorStyleToString(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	colorStyle, ok := d.Value.(*sheets.ColorStyle)
+	if !ok || colorStyle == nil {
+		return nil, nil
+	}
+	if colorStyle.RgbColor != nil {
+		return colorToHex(colorStyle.RgbColor), nil
+	}
+	if colorStyle.ThemeColor != "" {
+		return colorStyle.ThemeColor, nil
+	}
+	return nil, nil
+}
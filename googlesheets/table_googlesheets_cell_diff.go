@@ -0,0 +1,266 @@
+package googlesheets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type cellDiffInfo = struct {
+	SheetName          string
+	Cell               string
+	Column             string
+	Row                int
+	ChangeType         string
+	OldValue           *string
+	NewValue           *string
+	OldValueType       string
+	NewValueType       string
+	OldFormula         string
+	NewFormula         string
+	LeftSpreadsheetID  string
+	LeftRevisionID     string
+	RightSpreadsheetID string
+	RightRevisionID    string
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsCellDiff(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_cell_diff",
+		Description: "Compare the cells of two revisions of a spreadsheet, or of two spreadsheets.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetCellDiffs,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "left",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "right",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cell",
+				Description: "The address of the cell, e.g. A1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "col",
+				Description: "The column of the cell, e.g. A.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Column"),
+			},
+			{
+				Name:        "row",
+				Description: "The row of the cell.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "change_type",
+				Description: "The type of change of the cell, either added, removed or changed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "old_value",
+				Description: "The formatted value of the cell on the left side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "new_value",
+				Description: "The formatted value of the cell on the right side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "old_value_type",
+				Description: "The type of the value of the cell on the left side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "new_value_type",
+				Description: "The type of the value of the cell on the right side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "old_formula",
+				Description: "The formula of the cell on the left side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "new_formula",
+				Description: "The formula of the cell on the right side, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "left",
+				Description: "The version the cells are compared from, as a spreadsheet ID and/or a revision ID in the form [spreadsheet_id][@revision_id], e.g. @123 for a revision of the configured spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("left"),
+			},
+			{
+				Name:        "right",
+				Description: "The version the cells are compared to, in the same form as left. The current version of the spreadsheet is used if no revision ID is given.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("right"),
+			},
+			{
+				Name:        "left_spreadsheet_id",
+				Description: "The ID of the spreadsheet on the left side.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LeftSpreadsheetID"),
+			},
+			{
+				Name:        "left_revision_id",
+				Description: "The ID of the revision on the left side, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LeftRevisionID"),
+			},
+			{
+				Name:        "right_spreadsheet_id",
+				Description: "The ID of the spreadsheet on the right side.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RightSpreadsheetID"),
+			},
+			{
+				Name:        "right_revision_id",
+				Description: "The ID of the revision on the right side, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RightRevisionID"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetCellDiffs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	configuredID := getSpreadsheetIDStatic(ctx, d)
+	leftID, leftRevisionID := parseSpreadsheetVersion(d.EqualsQualString("left"), configuredID)
+	rightID, rightRevisionID := parseSpreadsheetVersion(d.EqualsQualString("right"), configuredID)
+
+	// Both sides are read from their XLSX export, so that the values are compared in the same format
+	var sheetNames []string
+	if d.EqualsQuals["sheet_name"] != nil {
+		sheetNames = []string{d.EqualsQualString("sheet_name")}
+	}
+	leftCells, err := getRevisionCells(ctx, d, leftID, leftRevisionID, nil, sheetNames)
+	if err != nil {
+		return nil, err
+	}
+	rightCells, err := getRevisionCells(ctx, d, rightID, rightRevisionID, nil, sheetNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, diff := range getCellDiffs(leftCells, rightCells) {
+		diff.LeftSpreadsheetID = leftID
+		diff.LeftRevisionID = leftRevisionID
+		diff.RightSpreadsheetID = rightID
+		diff.RightRevisionID = rightRevisionID
+		d.StreamListItem(ctx, diff)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseSpreadsheetVersion splits a version in the form [spreadsheet_id][@revision_id] into its spreadsheet and revision IDs
+// The configured spreadsheet is used if no spreadsheet ID is given, e.g. @123
+func parseSpreadsheetVersion(version string, defaultSpreadsheetID string) (string, string) {
+	spreadsheetID, revisionID, _ := strings.Cut(strings.TrimSpace(version), "@")
+	if spreadsheetID == "" {
+		spreadsheetID = defaultSpreadsheetID
+	}
+	return spreadsheetID, revisionID
+}
+
+// getCellDiffs returns the cells added, removed or changed between two lists of cells
+// Added and changed cells are returned in the order of the right side, followed by the removed cells in the order of the left side
+func getCellDiffs(leftCells []cellInfo, rightCells []cellInfo) []cellDiffInfo {
+	cellKey := func(cell cellInfo) string {
+		return fmt.Sprintf("%s!%s", cell.SheetName, cell.Cell)
+	}
+	newDiff := func(cell cellInfo, changeType string) cellDiffInfo {
+		return cellDiffInfo{
+			SheetName:  cell.SheetName,
+			Cell:       cell.Cell,
+			Column:     cell.Column,
+			Row:        cell.Row,
+			ChangeType: changeType,
+		}
+	}
+
+	leftByKey := map[string]cellInfo{}
+	for _, cell := range leftCells {
+		leftByKey[cellKey(cell)] = cell
+	}
+
+	var diffs []cellDiffInfo
+	rightKeys := map[string]bool{}
+	for _, right := range rightCells {
+		key := cellKey(right)
+		rightKeys[key] = true
+
+		left, ok := leftByKey[key]
+		if !ok {
+			diff := newDiff(right, "added")
+			diff.NewValue = &right.Value
+			diff.NewValueType = right.ValueType
+			diff.NewFormula = right.Formula
+			diffs = append(diffs, diff)
+			continue
+		}
+		if left.Value == right.Value && left.ValueType == right.ValueType && left.Formula == right.Formula {
+			continue
+		}
+		diff := newDiff(right, "changed")
+		diff.OldValue = &left.Value
+		diff.OldValueType = left.ValueType
+		diff.OldFormula = left.Formula
+		diff.NewValue = &right.Value
+		diff.NewValueType = right.ValueType
+		diff.NewFormula = right.Formula
+		diffs = append(diffs, diff)
+	}
+
+	for _, left := range leftCells {
+		if rightKeys[cellKey(left)] {
+			continue
+		}
+		diff := newDiff(left, "removed")
+		diff.OldValue = &left.Value
+		diff.OldValueType = left.ValueType
+		diff.OldFormula = left.Formula
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
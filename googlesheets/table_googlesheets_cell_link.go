@@ -0,0 +1,223 @@
+package googlesheets
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf16"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type cellLinkInfo = struct {
+	SheetName  string
+	Column     string
+	Row        int
+	Cell       string
+	Value      string
+	Text       string
+	Url        string
+	StartIndex int64
+	EndIndex   int64
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsCellLink(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_cell_link",
+		Description: "Retrieve the hyperlinks in the cells of a sheet in a spreadsheet.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetCellLinks,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "range",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "cell",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "col",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "row",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "col",
+				Description: "The ID of the column.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Column"),
+			},
+			{
+				Name:        "row",
+				Description: "The index of the row.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "cell",
+				Description: "The address of a cell.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "value",
+				Description: "The value of the cell containing the link.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "text",
+				Description: "The part of the cell value the link is applied to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "url",
+				Description: "The URI the link points to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "start_index",
+				Description: "The zero-based index of the first character of the link in the cell value, in UTF-16 code units.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("StartIndex"),
+			},
+			{
+				Name:        "end_index",
+				Description: "The zero-based index of the character following the link in the cell value, in UTF-16 code units.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("EndIndex"),
+			},
+			{
+				Name:        "range",
+				Description: "The ranges to retrieve from the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("range"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetCellLinks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetCellLinks", "connection_error", err)
+		return nil, err
+	}
+
+	// Get the ID of the spreadsheet
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("sheets(properties.title,data(rowData(values(formattedValue,userEnteredValue,hyperlink,textFormatRuns)),startColumn,startRow),merges)"))
+
+	// Additional filters
+	if ranges := getCellRanges(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+		// Ranges are applied by the API only when the sheet name is known
+		if !matchesCellQuals(d.EqualsQuals, sheetName, rowCount, colCount) {
+			return true
+		}
+
+		for _, link := range getCellLinks(sheetName, rowCount, colCount, cell) {
+			d.StreamListItem(ctx, link)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// getCellLinks returns all the links of a cell
+// Links applied to a part of the cell value are read from the text format runs, where adjacent runs with the same link are merged together
+// If the cell has no such link, the hyperlink of the whole cell is returned, if any
+func getCellLinks(sheetName string, rowCount int, colCount int, data *sheets.CellData) []cellLinkInfo {
+	var links []cellLinkInfo
+
+	// Run indexes are counted in UTF-16 code units
+	value := utf16.Encode([]rune(data.FormattedValue))
+	newLink := func(url string, startIndex int64, endIndex int64) cellLinkInfo {
+		startIndex = min(max(startIndex, 0), int64(len(value)))
+		endIndex = min(max(endIndex, startIndex), int64(len(value)))
+		return cellLinkInfo{
+			SheetName:  sheetName,
+			Column:     intToLetters(colCount + 1),
+			Row:        rowCount + 1,
+			Cell:       fmt.Sprintf("%s%d", intToLetters(colCount+1), rowCount+1),
+			Value:      data.FormattedValue,
+			Text:       string(utf16.Decode(value[startIndex:endIndex])),
+			Url:        url,
+			StartIndex: startIndex,
+			EndIndex:   endIndex,
+		}
+	}
+
+	// A run applies from its start index, until the start index of the next run
+	for idx, run := range data.TextFormatRuns {
+		if run.Format == nil || run.Format.Link == nil || run.Format.Link.Uri == "" {
+			continue
+		}
+		endIndex := int64(len(value))
+		if idx+1 < len(data.TextFormatRuns) {
+			endIndex = data.TextFormatRuns[idx+1].StartIndex
+		}
+
+		// The same link is split into multiple runs if only a part of it is formatted differently
+		if len(links) > 0 && links[len(links)-1].Url == run.Format.Link.Uri && links[len(links)-1].EndIndex == run.StartIndex {
+			links[len(links)-1] = newLink(run.Format.Link.Uri, links[len(links)-1].StartIndex, endIndex)
+			continue
+		}
+		links = append(links, newLink(run.Format.Link.Uri, run.StartIndex, endIndex))
+	}
+
+	if len(links) == 0 && data.Hyperlink != "" {
+		links = append(links, newLink(data.Hyperlink, 0, int64(len(value))))
+	}
+
+	return links
+}
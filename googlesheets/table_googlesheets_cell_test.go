@@ -0,0 +1,131 @@
+package googlesheets
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestIterateCells(t *testing.T) {
+	// Builds the grid data of a single column, with each cell set to its A1 notation
+	column := func(letter string, startRow int64, rows int) []*sheets.RowData {
+		rowData := make([]*sheets.RowData, 0, rows)
+		for i := 0; i < rows; i++ {
+			rowData = append(rowData, &sheets.RowData{Values: []*sheets.CellData{{FormattedValue: fmt.Sprintf("%s%d", letter, startRow+int64(i)+1)}}})
+		}
+		return rowData
+	}
+
+	tests := []struct {
+		name  string
+		data  *sheets.GridData
+		merge *sheets.GridRange
+		want  map[string]string
+	}{
+		{
+			name:  "merge at the start of the sheet",
+			data:  &sheets.GridData{RowData: column("A", 0, 3)},
+			merge: &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 0, EndColumnIndex: 1},
+			want:  map[string]string{"A1": "A1", "A2": "A1", "A3": "A3"},
+		},
+		{
+			name:  "merge inside a range that does not start at A1",
+			data:  &sheets.GridData{StartRow: 1, StartColumn: 1, RowData: column("B", 1, 9)},
+			merge: &sheets.GridRange{StartRowIndex: 4, EndRowIndex: 6, StartColumnIndex: 1, EndColumnIndex: 2},
+			want:  map[string]string{"B2": "B2", "B3": "B3", "B4": "B4", "B5": "B5", "B6": "B5", "B7": "B7", "B8": "B8", "B9": "B9", "B10": "B10"},
+		},
+		{
+			name:  "merge inside rows looked up by developer metadata",
+			data:  &sheets.GridData{StartRow: 4, RowData: column("A", 4, 2)},
+			merge: &sheets.GridRange{StartRowIndex: 4, EndRowIndex: 6, StartColumnIndex: 0, EndColumnIndex: 1},
+			want:  map[string]string{"A5": "A5", "A6": "A5"},
+		},
+		{
+			name:  "merge with its parent outside of the range",
+			data:  &sheets.GridData{StartRow: 5, StartColumn: 1, RowData: column("B", 5, 2)},
+			merge: &sheets.GridRange{StartRowIndex: 4, EndRowIndex: 7, StartColumnIndex: 1, EndColumnIndex: 2},
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &sheets.Spreadsheet{
+				Sheets: []*sheets.Sheet{{
+					Properties: &sheets.SheetProperties{Title: "Sheet1"},
+					Data:       []*sheets.GridData{tt.data},
+					Merges:     []*sheets.GridRange{tt.merge},
+				}},
+			}
+			got := map[string]string{}
+			iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+				got[fmt.Sprintf("%s%d", intToLetters(colCount+1), rowCount+1)] = cell.FormattedValue
+				return true
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("iterateCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesCellQuals(t *testing.T) {
+	stringQual := func(value string) *proto.QualValue {
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}}
+	}
+
+	tests := []struct {
+		name  string
+		quals plugin.KeyColumnEqualsQualMap
+		want  bool
+	}{
+		{
+			name:  "no quals",
+			quals: plugin.KeyColumnEqualsQualMap{},
+			want:  true,
+		},
+		{
+			name:  "sheet name",
+			quals: plugin.KeyColumnEqualsQualMap{"sheet_name": stringQual("Books")},
+			want:  true,
+		},
+		{
+			name:  "other sheet name",
+			quals: plugin.KeyColumnEqualsQualMap{"sheet_name": stringQual("Employees")},
+			want:  false,
+		},
+		{
+			name:  "lower case column",
+			quals: plugin.KeyColumnEqualsQualMap{"col": stringQual("b")},
+			want:  true,
+		},
+		{
+			name:  "absolute cell",
+			quals: plugin.KeyColumnEqualsQualMap{"cell": stringQual("$b$5")},
+			want:  true,
+		},
+		{
+			name:  "other cell",
+			quals: plugin.KeyColumnEqualsQualMap{"cell": stringQual("B6")},
+			want:  false,
+		},
+		{
+			name:  "row",
+			quals: plugin.KeyColumnEqualsQualMap{"row": &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: 5}}},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Cell B5 of the Books sheet
+			if got := matchesCellQuals(tt.quals, "Books", 4, 1); got != tt.want {
+				t.Errorf("matchesCellQuals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
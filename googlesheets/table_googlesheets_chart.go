@@ -0,0 +1,346 @@
+package googlesheets
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type chartInfo = struct {
+	SheetName    string
+	ChartType    string
+	AnchorCell   string
+	DomainRanges []string
+	SeriesRanges []string
+	Chart        *sheets.EmbeddedChart
+}
+
+type chartSourceCell = struct {
+	SheetName string `json:"sheet_name"`
+	Cell      string `json:"cell"`
+	Value     string `json:"value"`
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsChart(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_chart",
+		Description: "Retrieve the charts in a spreadsheet, along with the ranges of their source data.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetCharts,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet the chart is in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "chart_id",
+				Description: "The ID of the chart.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Chart.ChartId"),
+			},
+			{
+				Name:        "title",
+				Description: "The title of the chart.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Chart.Spec.Title"),
+			},
+			{
+				Name:        "subtitle",
+				Description: "The subtitle of the chart.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Chart.Spec.Subtitle"),
+			},
+			{
+				Name:        "chart_type",
+				Description: "The type of the chart, e.g. basic, pie, bubble, candlestick, histogram, org, treemap, waterfall or scorecard.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "basic_chart_type",
+				Description: "The type of a basic chart, e.g. BAR, LINE, AREA, COLUMN, SCATTER, COMBO or STEPPED_AREA.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Chart.Spec.BasicChart.ChartType"),
+			},
+			{
+				Name:        "new_sheet",
+				Description: "Indicates whether the chart is on its own sheet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Chart.Position.NewSheet"),
+			},
+			{
+				Name:        "anchor_cell",
+				Description: "The cell the chart is anchored to, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "offset_x_pixels",
+				Description: "The horizontal offset, in pixels, of the chart from its anchor cell.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Chart.Position.OverlayPosition.OffsetXPixels"),
+			},
+			{
+				Name:        "offset_y_pixels",
+				Description: "The vertical offset, in pixels, of the chart from its anchor cell.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Chart.Position.OverlayPosition.OffsetYPixels"),
+			},
+			{
+				Name:        "width_pixels",
+				Description: "The width of the chart, in pixels.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Chart.Position.OverlayPosition.WidthPixels"),
+			},
+			{
+				Name:        "height_pixels",
+				Description: "The height of the chart, in pixels.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Chart.Position.OverlayPosition.HeightPixels"),
+			},
+			{
+				Name:        "domain_ranges",
+				Description: "The source ranges of the domains of the chart, e.g. the labels of the X axis, in A1 notation.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "series_ranges",
+				Description: "The source ranges of the series of the chart, e.g. the values plotted on the Y axis, in A1 notation.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "source_data",
+				Description: "The non-empty cells of the source ranges of the chart.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getChartSourceData,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "spec",
+				Description: "The specification of the chart.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Chart.Spec"),
+			},
+			{
+				Name:        "position",
+				Description: "The position of the chart.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Chart.Position"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetCharts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetCharts", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	// The names of all the sheets are required to resolve the source ranges, which can be on other sheets
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties(sheetId,title),charts)")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	sheetNames := map[int64]string{}
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties != nil {
+			sheetNames[sheet.Properties.SheetId] = sheet.Properties.Title
+		}
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+
+		// Additional filters
+		if d.EqualsQuals["sheet_name"] != nil && d.EqualsQualString("sheet_name") != sheet.Properties.Title {
+			continue
+		}
+
+		for _, chart := range sheet.Charts {
+			d.StreamListItem(ctx, getChartInfo(sheet.Properties.Title, chart, sheetNames))
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getChartInfo(sheetName string, chart *sheets.EmbeddedChart, sheetNames map[int64]string) chartInfo {
+	info := chartInfo{
+		SheetName: sheetName,
+		Chart:     chart,
+	}
+
+	if chart.Position != nil && chart.Position.OverlayPosition != nil && chart.Position.OverlayPosition.AnchorCell != nil {
+		anchor := chart.Position.OverlayPosition.AnchorCell
+		info.AnchorCell = getA1Notation("", anchor.RowIndex, anchor.RowIndex+1, anchor.ColumnIndex, anchor.ColumnIndex+1)
+	}
+
+	spec := chart.Spec
+	if spec == nil {
+		return info
+	}
+
+	// Only one of the chart specs is set, depending on the type of the chart
+	var domains, series []*sheets.ChartData
+	switch {
+	case spec.BasicChart != nil:
+		info.ChartType = "basic"
+		for _, domain := range spec.BasicChart.Domains {
+			domains = append(domains, domain.Domain)
+		}
+		for _, s := range spec.BasicChart.Series {
+			series = append(series, s.Series)
+		}
+	case spec.PieChart != nil:
+		info.ChartType = "pie"
+		domains = append(domains, spec.PieChart.Domain)
+		series = append(series, spec.PieChart.Series)
+	case spec.BubbleChart != nil:
+		info.ChartType = "bubble"
+		domains = append(domains, spec.BubbleChart.Domain)
+		series = append(series, spec.BubbleChart.Series, spec.BubbleChart.BubbleSizes, spec.BubbleChart.BubbleLabels, spec.BubbleChart.GroupIds)
+	case spec.CandlestickChart != nil:
+		info.ChartType = "candlestick"
+		if spec.CandlestickChart.Domain != nil {
+			domains = append(domains, spec.CandlestickChart.Domain.Data)
+		}
+		for _, data := range spec.CandlestickChart.Data {
+			for _, s := range []*sheets.CandlestickSeries{data.LowSeries, data.OpenSeries, data.CloseSeries, data.HighSeries} {
+				if s != nil {
+					series = append(series, s.Data)
+				}
+			}
+		}
+	case spec.HistogramChart != nil:
+		info.ChartType = "histogram"
+		for _, s := range spec.HistogramChart.Series {
+			series = append(series, s.Data)
+		}
+	case spec.OrgChart != nil:
+		info.ChartType = "org"
+		domains = append(domains, spec.OrgChart.Labels)
+		series = append(series, spec.OrgChart.ParentLabels, spec.OrgChart.Tooltips)
+	case spec.TreemapChart != nil:
+		info.ChartType = "treemap"
+		domains = append(domains, spec.TreemapChart.Labels)
+		series = append(series, spec.TreemapChart.ParentLabels, spec.TreemapChart.SizeData, spec.TreemapChart.ColorData)
+	case spec.WaterfallChart != nil:
+		info.ChartType = "waterfall"
+		if spec.WaterfallChart.Domain != nil {
+			domains = append(domains, spec.WaterfallChart.Domain.Data)
+		}
+		for _, s := range spec.WaterfallChart.Series {
+			series = append(series, s.Data)
+		}
+	case spec.ScorecardChart != nil:
+		info.ChartType = "scorecard"
+		series = append(series, spec.ScorecardChart.KeyValueData, spec.ScorecardChart.BaselineValueData)
+	}
+	info.DomainRanges = getChartDataRanges(domains, sheetNames)
+	info.SeriesRanges = getChartDataRanges(series, sheetNames)
+
+	return info
+}
+
+// Returns the source ranges of the given chart data in A1 notation
+// Data of charts based on data sources refers to columns instead of ranges, and is skipped
+func getChartDataRanges(data []*sheets.ChartData, sheetNames map[int64]string) []string {
+	var ranges []string
+	for _, d := range data {
+		if d == nil || d.SourceRange == nil {
+			continue
+		}
+		for _, r := range d.SourceRange.Sources {
+			ranges = append(ranges, getA1Notation(sheetNames[r.SheetId], r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex))
+		}
+	}
+	return ranges
+}
+
+//// HYDRATE FUNCTIONS
+
+func getChartSourceData(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	info := h.Item.(chartInfo)
+	// Domains and series can share the same ranges, e.g. the header row of a table
+	var ranges []string
+	for _, r := range append(append([]string{}, info.DomainRanges...), info.SeriesRanges...) {
+		if !slices.Contains(ranges, r) {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getChartSourceData", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	data, err := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Ranges(ranges...).Fields(googleapi.Field("properties(timeZone,locale),sheets(properties.title,data(rowData(values(formattedValue,effectiveValue,userEnteredValue,effectiveFormat/numberFormat)),startColumn,startRow),merges)")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cells are read the same way as in the googlesheets_cell table, e.g. merged cells hold the value of their parent
+	settings := newSpreadsheetSettings(data.Properties)
+	cells := []chartSourceCell{}
+	iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+		info := getCellInfo(sheetName, rowCount, colCount, cell, settings)
+		cells = append(cells, chartSourceCell{SheetName: info.SheetName, Cell: info.Cell, Value: info.Value})
+		return true
+	})
+
+	return cells, nil
+}
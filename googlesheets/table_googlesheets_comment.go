@@ -0,0 +1,152 @@
+package googlesheets
+
+import (
+	"context"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsComment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_comment",
+		Description: "Retrieve the comments of the spreadsheet, along with their replies.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetComments,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the comment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "author_name",
+				Description: "The name of the author of the comment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Author.DisplayName"),
+			},
+			{
+				Name:        "author_email",
+				Description: "The email address of the author of the comment, if available.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Author.EmailAddress"),
+			},
+			{
+				Name:        "content",
+				Description: "The plain text content of the comment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Content"),
+			},
+			{
+				Name:        "html_content",
+				Description: "The content of the comment with HTML formatting.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("HtmlContent"),
+			},
+			{
+				Name:        "resolved",
+				Description: "Indicates whether the comment has been resolved by one of its replies.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Resolved"),
+			},
+			{
+				Name:        "created_time",
+				Description: "The time at which the comment was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("CreatedTime"),
+			},
+			{
+				Name:        "modified_time",
+				Description: "The last time the comment or any of its replies was modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("ModifiedTime"),
+			},
+			{
+				Name:        "quoted_content",
+				Description: "The content of the spreadsheet the comment refers to, e.g. the value of the commented cell.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("QuotedFileContent.Value"),
+			},
+			{
+				Name:        "anchor",
+				Description: "The region of the spreadsheet the comment refers to, as a JSON string. The format of the anchor isn't documented, and the anchors of comments added in the Sheets UI only hold an internal ID.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anchor"),
+			},
+			{
+				Name:        "reply_count",
+				Description: "The number of replies to the comment.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Replies").Transform(replyCount),
+			},
+			{
+				Name:        "replies",
+				Description: "The replies to the comment, in chronological order.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Replies"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetComments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetComments", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Comments.List(spreadsheetID).PageSize(100).Fields("nextPageToken,comments(*)")
+	err = resp.Pages(ctx, func(page *drive.CommentList) error {
+		for _, comment := range page.Comments {
+			d.StreamListItem(ctx, comment)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				page.NextPageToken = ""
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func replyCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	replies, ok := d.Value.([]*drive.Reply)
+	if !ok {
+		return 0, nil
+	}
+	return len(replies), nil
+}
@@ -0,0 +1,240 @@
+package googlesheets
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type conditionalFormatInfo = struct {
+	SheetName string
+	RuleIndex int
+	Ranges    []string
+	RuleType  string
+	Rule      *sheets.ConditionalFormatRule
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsConditionalFormat(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_conditional_format",
+		Description: "Retrieve the conditional format rules of the sheets in a spreadsheet.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetConditionalFormats,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_index",
+				Description: "The zero-based index of the rule in the sheet. Rules with a lower index take precedence.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("RuleIndex"),
+			},
+			{
+				Name:        "ranges",
+				Description: "The ranges the rule applies to, in A1 notation.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "rule_type",
+				Description: "The type of the rule, either boolean (a format applied if a condition is met) or gradient (a color scale).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "condition_type",
+				Description: "The type of condition of a boolean rule, e.g. NUMBER_GREATER, TEXT_CONTAINS, BLANK or CUSTOM_FORMULA.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.BooleanRule.Condition.Type"),
+			},
+			{
+				Name:        "condition_values",
+				Description: "The values of the condition of a boolean rule, either user entered values, formulas or relative dates.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.BooleanRule.Condition").Transform(conditionValues),
+			},
+			{
+				Name:        "formula",
+				Description: "The formula of a boolean rule, if the condition type is CUSTOM_FORMULA.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.BooleanRule.Condition").Transform(customFormula),
+			},
+			{
+				Name:        "has_ref_error",
+				Description: "Indicates whether the rule refers to a deleted range, i.e. one of the values of its condition or color scale contains #REF!.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Rule").Transform(ruleHasRefError),
+			},
+			{
+				Name:        "background_color",
+				Description: "The background color applied by a boolean rule, as a hex string (e.g. #ff0000), or the name of a theme color.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.BooleanRule.Format.BackgroundColorStyle").Transform(colorStyleToString),
+			},
+			{
+				Name:        "text_format",
+				Description: "The text format applied by a boolean rule, e.g. bold, italic, strikethrough and foreground color.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.BooleanRule.Format.TextFormat"),
+			},
+			{
+				Name:        "format",
+				Description: "The format applied by a boolean rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.BooleanRule.Format"),
+			},
+			{
+				Name:        "gradient_min_point",
+				Description: "The starting point of the color scale of a gradient rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.GradientRule.Minpoint"),
+			},
+			{
+				Name:        "gradient_mid_point",
+				Description: "The optional midway point of the color scale of a gradient rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.GradientRule.Midpoint"),
+			},
+			{
+				Name:        "gradient_max_point",
+				Description: "The final point of the color scale of a gradient rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.GradientRule.Maxpoint"),
+			},
+			{
+				Name:        "rule",
+				Description: "The definition of the conditional format rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetConditionalFormats(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetConditionalFormats", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties.title,conditionalFormats)"))
+
+	// Additional filters
+	if ranges := getQualListValues(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range data.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		for idx, rule := range sheet.ConditionalFormats {
+			d.StreamListItem(ctx, getConditionalFormatInfo(sheet.Properties.Title, idx, rule))
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getConditionalFormatInfo(sheetName string, ruleIndex int, rule *sheets.ConditionalFormatRule) conditionalFormatInfo {
+	info := conditionalFormatInfo{
+		SheetName: sheetName,
+		RuleIndex: ruleIndex,
+		Rule:      rule,
+	}
+
+	// The ranges of a rule are always on the sheet of the rule
+	for _, r := range rule.Ranges {
+		info.Ranges = append(info.Ranges, getA1Notation(sheetName, r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex))
+	}
+
+	switch {
+	case rule.BooleanRule != nil:
+		info.RuleType = "boolean"
+	case rule.GradientRule != nil:
+		info.RuleType = "gradient"
+	}
+
+	return info
+}
+
+//// TRANSFORM FUNCTIONS
+
+func customFormula(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, ok := d.Value.(*sheets.BooleanCondition)
+	if !ok || condition == nil || condition.Type != "CUSTOM_FORMULA" || len(condition.Values) == 0 {
+		return nil, nil
+	}
+	return condition.Values[0].UserEnteredValue, nil
+}
+
+// ruleHasRefError returns true if a formula of a rule refers to a deleted range
+// Formulas are stored as user entered values, in which references to deleted ranges are replaced by #REF!
+func ruleHasRefError(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	rule, ok := d.Value.(*sheets.ConditionalFormatRule)
+	if !ok || rule == nil {
+		return false, nil
+	}
+	var values []string
+	if rule.BooleanRule != nil {
+		values = getConditionValues(rule.BooleanRule.Condition)
+	}
+	if rule.GradientRule != nil {
+		for _, point := range []*sheets.InterpolationPoint{rule.GradientRule.Minpoint, rule.GradientRule.Midpoint, rule.GradientRule.Maxpoint} {
+			if point != nil {
+				values = append(values, point.Value)
+			}
+		}
+	}
+	for _, value := range values {
+		if strings.Contains(value, "#REF!") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
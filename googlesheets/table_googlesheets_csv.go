@@ -2,43 +2,189 @@ package googlesheets
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// Names of the metadata columns of dynamic tables
+const (
+	rowNumberColumn = "_row"
+	rowHashColumn   = "_row_hash"
+	rowIDColumn     = "_row_id"
+)
+
+type sheetTableInfo = struct {
+	SheetName string
+	Table     *sheets.Table
+}
+
 func listSpreadsheetWithPath(ctx context.Context, p *plugin.TableMapData, sheetName string) func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 		// Get spreadsheet details
-		spreadsheetData, err := getSpreadsheetData(ctx, p, []string{sheetName})
+		spreadsheet, err := getSpreadsheetData(ctx, p, []string{sheetName})
 		if err != nil {
 			return nil, err
 		}
 		spreadsheetHeaders := googleSpreadsheetHeadersMap[sheetName]
+		columnTypes := googleSpreadsheetColumnTypesMap[sheetName]
+
+		// Dates are interpreted using the spreadsheet's time zone and locale
+		settings := newSpreadsheetSettings(spreadsheet.Properties)
+		googleSheetsConfig := GetConfig(d.Connection)
+		if googleSheetsConfig.SmartChipValues != nil {
+			settings.SmartChipValues = *googleSheetsConfig.SmartChipValues
+		}
+		var rowIDMetadataKey string
+		if googleSheetsConfig.RowIdMetadataKey != nil {
+			rowIDMetadataKey = *googleSheetsConfig.RowIdMetadataKey
+		}
 
-		for _, sheet := range spreadsheetData {
+		for _, sheet := range spreadsheet.Sheets {
 			for _, i := range sheet.Data {
+				// Named ranges don't necessarily start at A1
+				merges := getRelativeMerges(sheet.Merges, i)
 				for row_count, row := range i.RowData {
 					// Skip first row, or header
 					if row_count == 0 {
 						continue
 					}
-					rowData := map[string]string{}
+					rowData := map[string]interface{}{}
+					rowValues := make([]string, len(spreadsheetHeaders))
 					for col_count, value := range row.Values {
 						if col_count >= len(spreadsheetHeaders) {
 							continue
 						}
-						mergeRow, mergeColumn, parentRow, parentColumn := findMergeCells(sheet.Merges, int64(row_count+1), int64(col_count+1))
-						if mergeRow != nil && mergeColumn != nil {
+						columnType := proto.ColumnType_STRING
+						if col_count < len(columnTypes) {
+							columnType = columnTypes[col_count]
+						}
+						mergeRow, mergeColumn, parentRow, parentColumn := findMergeCells(merges, int64(row_count+1), int64(col_count+1))
+						// The parent of a merge can be outside of a named range
+						if mergeRow != nil && mergeColumn != nil && *parentRow >= 1 && *parentColumn >= 1 && int(*parentColumn) <= len(i.RowData[*parentRow-1].Values) {
 							parentData := i.RowData[*parentRow-1].Values[*parentColumn-1]
-							rowData[spreadsheetHeaders[col_count]] = parentData.FormattedValue
+							rowData[spreadsheetHeaders[col_count]] = getRowColumnValue(ctx, sheetName, spreadsheetHeaders[col_count], row_count+int(i.StartRow)+1, parentData, columnType, settings)
+							rowValues[col_count] = parentData.FormattedValue
 						} else {
-							rowData[spreadsheetHeaders[col_count]] = value.FormattedValue
+							rowData[spreadsheetHeaders[col_count]] = getRowColumnValue(ctx, sheetName, spreadsheetHeaders[col_count], row_count+int(i.StartRow)+1, value, columnType, settings)
+							rowValues[col_count] = value.FormattedValue
+						}
+					}
+					addRowMetadata(rowData, spreadsheetHeaders, row_count+int(i.StartRow)+1, rowValues, getRowID(i.RowMetadata, row_count, rowIDMetadataKey))
+					d.StreamListItem(ctx, rowData)
+				}
+			}
+		}
+		return nil, nil
+	}
+}
+
+func listSheetTableWithPath(ctx context.Context, p *plugin.TableMapData, tableName string) func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		// The range of a table grows as rows are added, so it is looked up again for each query
+		sheetTables, err := getSpreadsheetTables(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		var tableRange string
+		for _, sheetTable := range sheetTables {
+			if sheetTable.Table.Name == tableName {
+				r := sheetTable.Table.Range
+				tableRange = getA1Notation(sheetTable.SheetName, r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex)
+				break
+			}
+		}
+		if tableRange == "" {
+			return nil, nil
+		}
+
+		// Get spreadsheet details
+		spreadsheet, err := getSpreadsheetData(ctx, p, []string{tableRange})
+		if err != nil {
+			return nil, err
+		}
+		spreadsheetHeaders := googleSpreadsheetHeadersMap[tableName]
+		columnTypes := googleSpreadsheetColumnTypesMap[tableName]
+
+		// Dates are interpreted using the spreadsheet's time zone and locale
+		settings := newSpreadsheetSettings(spreadsheet.Properties)
+		googleSheetsConfig := GetConfig(d.Connection)
+		if googleSheetsConfig.SmartChipValues != nil {
+			settings.SmartChipValues = *googleSheetsConfig.SmartChipValues
+		}
+		var rowIDMetadataKey string
+		if googleSheetsConfig.RowIdMetadataKey != nil {
+			rowIDMetadataKey = *googleSheetsConfig.RowIdMetadataKey
+		}
+
+		for _, sheet := range spreadsheet.Sheets {
+			for _, i := range sheet.Data {
+				for row_count, row := range i.RowData {
+					// Skip first row, or header
+					if row_count == 0 {
+						continue
+					}
+					rowData := map[string]interface{}{}
+					rowValues := make([]string, len(spreadsheetHeaders))
+					for col_count, value := range row.Values {
+						if col_count >= len(spreadsheetHeaders) || col_count >= len(columnTypes) {
+							continue
 						}
+						rowData[spreadsheetHeaders[col_count]] = getRowColumnValue(ctx, tableName, spreadsheetHeaders[col_count], row_count+int(i.StartRow)+1, value, columnTypes[col_count], settings)
+						rowValues[col_count] = value.FormattedValue
 					}
+					addRowMetadata(rowData, spreadsheetHeaders, row_count+int(i.StartRow)+1, rowValues, getRowID(i.RowMetadata, row_count, rowIDMetadataKey))
 					d.StreamListItem(ctx, rowData)
+
+					// Context can be cancelled due to manual cancellation or the limit has been hit
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
 				}
 			}
 		}
 		return nil, nil
 	}
 }
+
+// getRowColumnValue converts a cell into the value of a column of a dynamic table
+// Column types are inferred when the schema is loaded, so a value entered later that doesn't match the type of its column is returned as null, and logged
+func getRowColumnValue(ctx context.Context, tableName string, columnName string, rowNumber int, cell *sheets.CellData, columnType proto.ColumnType, settings *spreadsheetSettings) interface{} {
+	value := getColumnValue(cell, columnType, settings)
+	if value == nil && columnType != proto.ColumnType_STRING && cell != nil && cell.FormattedValue != "" {
+		plugin.Logger(ctx).Warn("getRowColumnValue", "table", tableName, "column", columnName, "row", rowNumber, "type_mismatch", fmt.Sprintf("value doesn't match the column type %s, returning null", columnType))
+	}
+	return value
+}
+
+// addRowMetadata adds the number of the row in the sheet, the hash of its values and its ID to the data of a row
+// Metadata columns are skipped if a header has the same name
+func addRowMetadata(rowData map[string]interface{}, headers []string, rowNumber int, rowValues []string, rowID *string) {
+	if !slices.Contains(headers, rowNumberColumn) {
+		rowData[rowNumberColumn] = rowNumber
+	}
+	if !slices.Contains(headers, rowHashColumn) {
+		rowData[rowHashColumn] = getRowHash(rowValues)
+	}
+	if rowID != nil && !slices.Contains(headers, rowIDColumn) {
+		rowData[rowIDColumn] = *rowID
+	}
+}
+
+// getRowID returns the value of the developer metadata with the given key of a row, if any
+// The plugin never writes developer metadata, so rows which haven't been tagged have no ID
+func getRowID(rowMetadata []*sheets.DimensionProperties, rowIndex int, key string) *string {
+	if key == "" || rowIndex >= len(rowMetadata) || rowMetadata[rowIndex] == nil {
+		return nil
+	}
+	for _, metadata := range rowMetadata[rowIndex].DeveloperMetadata {
+		if metadata.MetadataKey == key {
+			return &metadata.MetadataValue
+		}
+	}
+	return nil
+}
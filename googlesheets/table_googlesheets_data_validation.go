@@ -0,0 +1,272 @@
+package googlesheets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type dataValidationInfo = struct {
+	SheetName        string
+	Range            string
+	StartRowIndex    int64
+	EndRowIndex      int64
+	StartColumnIndex int64
+	EndColumnIndex   int64
+	CellCount        int64
+	Rule             *sheets.DataValidationRule
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsDataValidation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_data_validation",
+		Description: "Retrieve the data validation rules of the sheets in a spreadsheet, grouped by ranges of contiguous cells sharing the same rule.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetDataValidations,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "range",
+				Description: "The range of cells the rule applies to, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "condition_type",
+				Description: "The type of condition the data in the cells must match, e.g. ONE_OF_LIST, ONE_OF_RANGE, NUMBER_BETWEEN or BOOLEAN.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.Condition.Type"),
+			},
+			{
+				Name:        "condition_values",
+				Description: "The values of the condition, either user entered values or relative dates.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.Condition").Transform(conditionValues),
+			},
+			{
+				Name:        "allowed_values",
+				Description: "The values allowed in the cells, if the condition type is ONE_OF_LIST.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.Condition").Transform(allowedValues),
+			},
+			{
+				Name:        "source_range",
+				Description: "The range holding the values allowed in the cells in A1 notation, if the condition type is ONE_OF_RANGE.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.Condition").Transform(sourceRange),
+			},
+			{
+				Name:        "strict",
+				Description: "Indicates whether invalid data is rejected, or only flagged with a warning.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Rule.Strict"),
+			},
+			{
+				Name:        "input_message",
+				Description: "A message to show the user when adding data to the cells.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.InputMessage"),
+			},
+			{
+				Name:        "show_custom_ui",
+				Description: "Indicates whether the UI is customized based on the kind of condition, e.g. a dropdown for lists.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Rule.ShowCustomUi"),
+			},
+			{
+				Name:        "cell_count",
+				Description: "The number of cells in the range.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "rule",
+				Description: "The data validation rule.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetDataValidations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetDataValidations", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("sheets(properties.title,data(rowData(values(dataValidation)),startColumn,startRow))"))
+
+	// Additional filters
+	if ranges := getQualListValues(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range data.Sheets {
+		for _, validation := range getDataValidationRanges(sheet) {
+			d.StreamListItem(ctx, validation)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// getDataValidationRanges groups the cells of a sheet sharing the same data validation rule into rectangular ranges
+// Contiguous cells of a row are grouped first, then the groups spanning the same columns are merged across consecutive rows
+func getDataValidationRanges(sheet *sheets.Sheet) []*dataValidationInfo {
+	var validations []*dataValidationInfo
+	if sheet == nil || sheet.Properties == nil {
+		return nil
+	}
+
+	for _, i := range sheet.Data {
+		// Ranges which can still be extended by the next row, keyed by their columns and rule
+		active := map[string]*dataValidationInfo{}
+		for rowCount, row := range i.RowData {
+			rowIndex := int64(rowCount) + i.StartRow
+			next := map[string]*dataValidationInfo{}
+
+			for colCount := 0; colCount < len(row.Values); {
+				rule := row.Values[colCount].DataValidation
+				if rule == nil {
+					colCount++
+					continue
+				}
+
+				// Find the end of the group of cells sharing the same rule within the row
+				ruleKey := getDataValidationRuleKey(rule)
+				end := colCount + 1
+				for end < len(row.Values) && row.Values[end].DataValidation != nil && getDataValidationRuleKey(row.Values[end].DataValidation) == ruleKey {
+					end++
+				}
+				startColumn, endColumn := int64(colCount)+i.StartColumn, int64(end)+i.StartColumn
+				colCount = end
+
+				key := fmt.Sprintf("%d:%d:%s", startColumn, endColumn, ruleKey)
+				if validation, ok := active[key]; ok && validation.EndRowIndex == rowIndex {
+					validation.EndRowIndex = rowIndex + 1
+					next[key] = validation
+					continue
+				}
+				validation := &dataValidationInfo{
+					SheetName:        sheet.Properties.Title,
+					StartRowIndex:    rowIndex,
+					EndRowIndex:      rowIndex + 1,
+					StartColumnIndex: startColumn,
+					EndColumnIndex:   endColumn,
+					Rule:             rule,
+				}
+				validations = append(validations, validation)
+				next[key] = validation
+			}
+			active = next
+		}
+	}
+
+	for _, validation := range validations {
+		validation.Range = getA1Notation(validation.SheetName, validation.StartRowIndex, validation.EndRowIndex, validation.StartColumnIndex, validation.EndColumnIndex)
+		validation.CellCount = (validation.EndRowIndex - validation.StartRowIndex) * (validation.EndColumnIndex - validation.StartColumnIndex)
+	}
+
+	return validations
+}
+
+// Returns a key identifying a data validation rule, so that cells sharing the same rule can be grouped together
+func getDataValidationRuleKey(rule *sheets.DataValidationRule) string {
+	key, err := json.Marshal(rule)
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+// Returns the values of a condition, using the relative date (e.g. TODAY or PAST_WEEK) if no value has been entered
+func getConditionValues(condition *sheets.BooleanCondition) []string {
+	if condition == nil {
+		return nil
+	}
+	var values []string
+	for _, value := range condition.Values {
+		if value.UserEnteredValue != "" {
+			values = append(values, value.UserEnteredValue)
+		} else {
+			values = append(values, value.RelativeDate)
+		}
+	}
+	return values
+}
+
+//// TRANSFORM FUNCTIONS
+
+func conditionValues(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, ok := d.Value.(*sheets.BooleanCondition)
+	if !ok {
+		return nil, nil
+	}
+	return getConditionValues(condition), nil
+}
+
+func allowedValues(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, ok := d.Value.(*sheets.BooleanCondition)
+	if !ok || condition == nil || condition.Type != "ONE_OF_LIST" {
+		return nil, nil
+	}
+	return getConditionValues(condition), nil
+}
+
+func sourceRange(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	condition, ok := d.Value.(*sheets.BooleanCondition)
+	if !ok || condition == nil || condition.Type != "ONE_OF_RANGE" || len(condition.Values) == 0 {
+		return nil, nil
+	}
+
+	// The range is entered as a reference, e.g. =Sheet1!A1:A10
+	return strings.TrimPrefix(condition.Values[0].UserEnteredValue, "="), nil
+}
@@ -0,0 +1,276 @@
+package googlesheets
+
+import (
+	"context"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Location types of developer metadata, searched separately if no location type is given
+var developerMetadataLocationTypes = []string{"SPREADSHEET", "SHEET", "ROW", "COLUMN"}
+
+type developerMetadataInfo = struct {
+	SheetName         string
+	Range             string
+	DeveloperMetadata *sheets.DeveloperMetadata
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsDeveloperMetadata(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_developer_metadata",
+		Description: "Retrieve the developer metadata associated with the spreadsheet, its sheets, rows and columns.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetDeveloperMetadata,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "metadata_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "metadata_key",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "metadata_value",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "visibility",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "location_type",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "metadata_id",
+				Description: "The ID of the developer metadata, unique within the spreadsheet.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("DeveloperMetadata.MetadataId"),
+			},
+			{
+				Name:        "metadata_key",
+				Description: "The key of the developer metadata.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DeveloperMetadata.MetadataKey"),
+			},
+			{
+				Name:        "metadata_value",
+				Description: "The value of the developer metadata.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DeveloperMetadata.MetadataValue"),
+			},
+			{
+				Name:        "visibility",
+				Description: "The visibility of the developer metadata, either DOCUMENT or PROJECT. Metadata with PROJECT visibility is only visible to the Google Cloud project which created it.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DeveloperMetadata.Visibility"),
+			},
+			{
+				Name:        "location_type",
+				Description: "The type of location the developer metadata is associated with, either SPREADSHEET, SHEET, ROW or COLUMN.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DeveloperMetadata.Location.LocationType"),
+			},
+			{
+				Name:        "sheet_id",
+				Description: "The ID of the sheet the developer metadata is associated with, if any.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromP(developerMetadataLocationField, "sheet_id"),
+			},
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet the developer metadata is associated with, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dimension",
+				Description: "The dimension the developer metadata is associated with, either ROWS or COLUMNS.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DeveloperMetadata.Location.DimensionRange.Dimension"),
+			},
+			{
+				Name:        "start_index",
+				Description: "The zero-based index of the first row or column the developer metadata is associated with.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromP(developerMetadataLocationField, "start_index"),
+			},
+			{
+				Name:        "end_index",
+				Description: "The zero-based index of the row or column after the last one the developer metadata is associated with.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromP(developerMetadataLocationField, "end_index"),
+			},
+			{
+				Name:        "range",
+				Description: "The range the developer metadata is associated with, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "location",
+				Description: "The location the developer metadata is associated with.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("DeveloperMetadata.Location"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetDeveloperMetadata(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetDeveloperMetadata", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	// The names of the sheets are only required to resolve the locations of the metadata
+	var sheetNames map[int64]string
+	if isColumnRequested(d, "sheet_name", "range") {
+		resp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties(sheetId,title))")).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		sheetNames = map[int64]string{}
+		for _, sheet := range resp.Sheets {
+			sheetNames[sheet.Properties.SheetId] = sheet.Properties.Title
+		}
+	}
+
+	resp, err := svc.Spreadsheets.DeveloperMetadata.Search(spreadsheetID, &sheets.SearchDeveloperMetadataRequest{
+		DataFilters: getDeveloperMetadataFilters(d.EqualsQuals),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, matched := range resp.MatchedDeveloperMetadata {
+		metadata := matched.DeveloperMetadata
+		if metadata == nil {
+			continue
+		}
+		sheetName, a1Range := resolveDeveloperMetadataLocation(metadata.Location, sheetNames)
+		d.StreamListItem(ctx, developerMetadataInfo{SheetName: sheetName, Range: a1Range, DeveloperMetadata: metadata})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getDeveloperMetadataFilters builds the data filters of the search from the quals of the table
+// A lookup matches a single location type, so one filter is used per location type if none is given
+func getDeveloperMetadataFilters(quals plugin.KeyColumnEqualsQualMap) []*sheets.DataFilter {
+	lookup := sheets.DeveloperMetadataLookup{}
+	if quals["metadata_id"] != nil {
+		lookup.MetadataId = quals["metadata_id"].GetInt64Value()
+	}
+	if quals["metadata_key"] != nil {
+		lookup.MetadataKey = quals["metadata_key"].GetStringValue()
+	}
+	if quals["metadata_value"] != nil {
+		lookup.MetadataValue = quals["metadata_value"].GetStringValue()
+	}
+	if quals["visibility"] != nil {
+		lookup.Visibility = quals["visibility"].GetStringValue()
+	}
+
+	locationTypes := developerMetadataLocationTypes
+	if quals["location_type"] != nil {
+		locationTypes = []string{quals["location_type"].GetStringValue()}
+	}
+
+	var filters []*sheets.DataFilter
+	for _, locationType := range locationTypes {
+		filterLookup := lookup
+		filterLookup.LocationType = locationType
+		filters = append(filters, &sheets.DataFilter{DeveloperMetadataLookup: &filterLookup})
+	}
+	return filters
+}
+
+// resolveDeveloperMetadataLocation returns the sheet name and the range in A1 notation of the location of developer metadata
+func resolveDeveloperMetadataLocation(location *sheets.DeveloperMetadataLocation, sheetNames map[int64]string) (string, string) {
+	if location == nil || sheetNames == nil {
+		return "", ""
+	}
+	switch {
+	case location.DimensionRange != nil:
+		r := location.DimensionRange
+		sheetName, ok := sheetNames[r.SheetId]
+		if !ok {
+			return "", ""
+		}
+		if r.Dimension == "COLUMNS" {
+			return sheetName, getA1Notation(sheetName, 0, 0, r.StartIndex, r.EndIndex)
+		}
+		return sheetName, getA1Notation(sheetName, r.StartIndex, r.EndIndex, 0, 0)
+	case location.LocationType == "SHEET":
+		sheetName, ok := sheetNames[location.SheetId]
+		if !ok {
+			return "", ""
+		}
+		return sheetName, quoteSheetName(sheetName)
+	}
+	return "", ""
+}
+
+//// TRANSFORM FUNCTIONS
+
+// developerMetadataLocationField returns the sheet ID or a dimension index of the location of developer metadata
+// Zero values are omitted by the API, so they are only returned as 0 for the locations they apply to
+func developerMetadataLocationField(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	info, ok := d.HydrateItem.(developerMetadataInfo)
+	if !ok || info.DeveloperMetadata == nil || info.DeveloperMetadata.Location == nil {
+		return nil, nil
+	}
+	location := info.DeveloperMetadata.Location
+	switch d.Param.(string) {
+	case "sheet_id":
+		if location.DimensionRange != nil {
+			return location.DimensionRange.SheetId, nil
+		}
+		if location.LocationType == "SHEET" {
+			return location.SheetId, nil
+		}
+	case "start_index":
+		if location.DimensionRange != nil {
+			return location.DimensionRange.StartIndex, nil
+		}
+	case "end_index":
+		if location.DimensionRange != nil {
+			return location.DimensionRange.EndIndex, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+package googlesheets
+
+import (
+	"context"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type namedRangeInfo = struct {
+	Name         string
+	NamedRangeId string
+	SheetName    string
+	SheetId      int64
+	Range        string
+	CellCount    int64
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsNamedRange(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_named_range",
+		Description: "Retrieve the named ranges defined in a spreadsheet.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetNamedRanges,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the named range.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "named_range_id",
+				Description: "The ID of the named range.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet the named range is defined on.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sheet_id",
+				Description: "The ID (gid) of the sheet the named range is defined on.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("SheetId"),
+			},
+			{
+				Name:        "range",
+				Description: "The range the named range refers to, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cell_count",
+				Description: "The number of cells in the named range.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "values",
+				Description: "The formatted values of the cells in the named range, as an array of rows.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getNamedRangeValues,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetNamedRanges(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetNamedRanges", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	namedRanges, err := getNamedRanges(ctx, svc, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namedRange := range namedRanges {
+		// Additional filters
+		if d.EqualsQuals["name"] != nil && d.EqualsQuals["name"].GetStringValue() != namedRange.Name {
+			continue
+		}
+
+		d.StreamListItem(ctx, namedRange)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getNamedRangeValues(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	namedRange := h.Item.(namedRangeInfo)
+
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getNamedRangeValues", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, namedRange.Name).ValueRenderOption("FORMATTED_VALUE").Fields(googleapi.Field("values")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Values, nil
+}
+
+// getNamedRanges returns the named ranges of a spreadsheet, along with the sheet they are defined on
+// Unbounded ranges (e.g. whole columns) are limited to the size of the sheet when counting their cells
+func getNamedRanges(ctx context.Context, svc *sheets.Service, spreadsheetID string) ([]namedRangeInfo, error) {
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("namedRanges,sheets(properties(sheetId,title,gridProperties(rowCount,columnCount)))")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	sheetProperties := map[int64]*sheets.SheetProperties{}
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties != nil {
+			sheetProperties[sheet.Properties.SheetId] = sheet.Properties
+		}
+	}
+
+	var namedRanges []namedRangeInfo
+	for _, namedRange := range resp.NamedRanges {
+		info := namedRangeInfo{
+			Name:         namedRange.Name,
+			NamedRangeId: namedRange.NamedRangeId,
+		}
+		if gridRange := namedRange.Range; gridRange != nil {
+			info.SheetId = gridRange.SheetId
+			endRow, endColumn := gridRange.EndRowIndex, gridRange.EndColumnIndex
+			if properties, ok := sheetProperties[gridRange.SheetId]; ok {
+				info.SheetName = properties.Title
+				if properties.GridProperties != nil {
+					if endRow == 0 {
+						endRow = properties.GridProperties.RowCount
+					}
+					if endColumn == 0 {
+						endColumn = properties.GridProperties.ColumnCount
+					}
+				}
+			}
+			info.Range = getA1Notation(info.SheetName, gridRange.StartRowIndex, gridRange.EndRowIndex, gridRange.StartColumnIndex, gridRange.EndColumnIndex)
+			info.CellCount = max(endRow-gridRange.StartRowIndex, 0) * max(endColumn-gridRange.StartColumnIndex, 0)
+		}
+		namedRanges = append(namedRanges, info)
+	}
+
+	return namedRanges, nil
+}
@@ -0,0 +1,190 @@
+package googlesheets
+
+import (
+	"context"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsPermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_permission",
+		Description: "Retrieve the permissions granted on the spreadsheet.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetPermissions,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the permission.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "role",
+				Description: "The role granted by the permission, e.g. owner, organizer, fileOrganizer, writer, commenter or reader.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the grantee, e.g. user, group, domain or anyone.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "email_address",
+				Description: "The email address of the user or group the permission refers to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain",
+				Description: "The domain the permission refers to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "The name of the user, group or domain the permission refers to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allow_file_discovery",
+				Description: "Indicates whether the spreadsheet can be discovered through search, for permissions of type domain or anyone.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AllowFileDiscovery"),
+			},
+			{
+				Name:        "expiration_time",
+				Description: "The time at which the permission expires.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "inherited",
+				Description: "Indicates whether the permission is inherited from the shared drive or a parent folder of the spreadsheet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("PermissionDetails").Transform(permissionInherited),
+			},
+			{
+				Name:        "inherited_from",
+				Description: "The ID of the item the permission is inherited from.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PermissionDetails").Transform(permissionInheritedFrom),
+			},
+			{
+				Name:        "pending_owner",
+				Description: "Indicates whether the account of the permission is a pending owner of the spreadsheet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("PendingOwner"),
+			},
+			{
+				Name:        "deleted",
+				Description: "Indicates whether the account of the permission has been deleted.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Deleted"),
+			},
+			{
+				Name:        "view",
+				Description: "The view of the permission, e.g. published for permissions of published spreadsheets.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "permission_details",
+				Description: "The details of whether the permission is inherited, and where it is inherited from.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetPermissions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetPermissions", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	permissions, err := getSpreadsheetPermissions(ctx, svc, spreadsheetID, d)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		d.StreamListItem(ctx, permission)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getSpreadsheetPermissions returns all the permissions of a spreadsheet, following the pages of the results
+// The results are limited to the rows remaining in the query, if any
+func getSpreadsheetPermissions(ctx context.Context, svc *drive.Service, spreadsheetID string, d *plugin.QueryData) ([]*drive.Permission, error) {
+	var permissions []*drive.Permission
+	resp := svc.Permissions.List(spreadsheetID).SupportsAllDrives(true).PageSize(100).Fields("nextPageToken,permissions(*)")
+	err := resp.Pages(ctx, func(page *drive.PermissionList) error {
+		permissions = append(permissions, page.Permissions...)
+
+		// Stop paging once the limit has been hit
+		if d != nil && d.QueryContext.Limit != nil && int64(len(permissions)) >= *d.QueryContext.Limit {
+			page.NextPageToken = ""
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func permissionInherited(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	details, ok := d.Value.([]*drive.PermissionPermissionDetails)
+	if !ok {
+		return false, nil
+	}
+	for _, detail := range details {
+		if detail.Inherited {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func permissionInheritedFrom(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	details, ok := d.Value.([]*drive.PermissionPermissionDetails)
+	if !ok {
+		return nil, nil
+	}
+	for _, detail := range details {
+		if detail.Inherited && detail.InheritedFrom != "" {
+			return detail.InheritedFrom, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,277 @@
+package googlesheets
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type pivotTableInfo = struct {
+	SheetName    string
+	AnchorCell   string
+	SourceRange  string
+	RowGroups    []pivotGroupInfo
+	ColumnGroups []pivotGroupInfo
+	PivotValues  []pivotValueInfo
+	Filters      []pivotFilterInfo
+	PivotTable   *sheets.PivotTable
+}
+
+type pivotGroupInfo = struct {
+	SourceColumn string `json:"source_column"`
+	Label        string `json:"label,omitempty"`
+	SortOrder    string `json:"sort_order,omitempty"`
+	ShowTotals   bool   `json:"show_totals"`
+}
+
+type pivotValueInfo = struct {
+	SourceColumn          string `json:"source_column,omitempty"`
+	Name                  string `json:"name,omitempty"`
+	SummarizeFunction     string `json:"summarize_function"`
+	Formula               string `json:"formula,omitempty"`
+	CalculatedDisplayType string `json:"calculated_display_type,omitempty"`
+}
+
+type pivotFilterInfo = struct {
+	SourceColumn     string                   `json:"source_column"`
+	VisibleValues    []string                 `json:"visible_values,omitempty"`
+	Condition        *sheets.BooleanCondition `json:"condition,omitempty"`
+	VisibleByDefault bool                     `json:"visible_by_default"`
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsPivotTable(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_pivot_table",
+		Description: "Retrieve the pivot tables in a spreadsheet, along with their groups, values and filters.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetPivotTables,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet the pivot table is in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "anchor_cell",
+				Description: "The cell the pivot table is anchored to, i.e. its top left cell, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_range",
+				Description: "The range the pivot table reads its data from, in A1 notation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "data_source_id",
+				Description: "The ID of the data source the pivot table reads its data from, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PivotTable.DataSourceId"),
+			},
+			{
+				Name:        "value_layout",
+				Description: "Whether the values are listed horizontally (as columns) or vertically (as rows).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PivotTable.ValueLayout"),
+			},
+			{
+				Name:        "row_groups",
+				Description: "The row groups of the pivot table, with the source column each group is built from.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "column_groups",
+				Description: "The column groups of the pivot table, with the source column each group is built from.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "pivot_values",
+				Description: "The values of the pivot table, with the source column and the function summarizing each value, or the formula of calculated values.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "filters",
+				Description: "The filters of the pivot table, with the source column and the criteria of each filter.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "pivot_table",
+				Description: "The definition of the pivot table.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("PivotTable"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetPivotTables(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetPivotTables", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	// The names of all the sheets are required to resolve the source ranges, which are usually on other sheets
+	sheetsResp, err := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties(sheetId,title))")).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	sheetNames := map[int64]string{}
+	for _, sheet := range sheetsResp.Sheets {
+		sheetNames[sheet.Properties.SheetId] = sheet.Properties.Title
+	}
+
+	// Pivot tables are stored in the cell they are anchored to
+	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("sheets(properties.title,data(rowData(values(pivotTable)),startColumn,startRow))"))
+
+	// Additional filters
+	if ranges := getQualListValues(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range data.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		for _, grid := range sheet.Data {
+			for rowIndex, row := range grid.RowData {
+				for colIndex, value := range row.Values {
+					if value.PivotTable == nil {
+						continue
+					}
+					anchorRow := int64(rowIndex) + grid.StartRow
+					anchorColumn := int64(colIndex) + grid.StartColumn
+					anchorCell := getA1Notation("", anchorRow, anchorRow+1, anchorColumn, anchorColumn+1)
+					d.StreamListItem(ctx, getPivotTableInfo(sheet.Properties.Title, anchorCell, value.PivotTable, sheetNames))
+
+					// Context can be cancelled due to manual cancellation or the limit has been hit
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getPivotTableInfo(sheetName string, anchorCell string, pivotTable *sheets.PivotTable, sheetNames map[int64]string) pivotTableInfo {
+	info := pivotTableInfo{
+		SheetName:  sheetName,
+		AnchorCell: anchorCell,
+		PivotTable: pivotTable,
+	}
+
+	// The columns of the source are referred to by their offset from the first column of the source range
+	var startColumn int64
+	if r := pivotTable.Source; r != nil {
+		info.SourceRange = getA1Notation(sheetNames[r.SheetId], r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex)
+		startColumn = r.StartColumnIndex
+	}
+	getSourceColumn := func(offset int64, reference *sheets.DataSourceColumnReference) string {
+		if reference != nil {
+			return reference.Name
+		}
+		return intToLetters(int(startColumn+offset) + 1)
+	}
+
+	getGroups := func(groups []*sheets.PivotGroup) []pivotGroupInfo {
+		var infos []pivotGroupInfo
+		for _, group := range groups {
+			infos = append(infos, pivotGroupInfo{
+				SourceColumn: getSourceColumn(group.SourceColumnOffset, group.DataSourceColumnReference),
+				Label:        group.Label,
+				SortOrder:    group.SortOrder,
+				ShowTotals:   group.ShowTotals,
+			})
+		}
+		return infos
+	}
+	info.RowGroups = getGroups(pivotTable.Rows)
+	info.ColumnGroups = getGroups(pivotTable.Columns)
+
+	for _, value := range pivotTable.Values {
+		valueInfo := pivotValueInfo{
+			Name:                  value.Name,
+			SummarizeFunction:     value.SummarizeFunction,
+			Formula:               value.Formula,
+			CalculatedDisplayType: value.CalculatedDisplayType,
+		}
+		// Calculated values are based on a formula instead of a source column
+		if value.Formula == "" {
+			valueInfo.SourceColumn = getSourceColumn(value.SourceColumnOffset, value.DataSourceColumnReference)
+		}
+		info.PivotValues = append(info.PivotValues, valueInfo)
+	}
+
+	for _, filter := range pivotTable.FilterSpecs {
+		filterInfo := pivotFilterInfo{
+			SourceColumn: getSourceColumn(filter.ColumnOffsetIndex, filter.DataSourceColumnReference),
+		}
+		if filter.FilterCriteria != nil {
+			filterInfo.VisibleValues = filter.FilterCriteria.VisibleValues
+			filterInfo.Condition = filter.FilterCriteria.Condition
+			filterInfo.VisibleByDefault = filter.FilterCriteria.VisibleByDefault
+		}
+		info.Filters = append(info.Filters, filterInfo)
+	}
+
+	// Older pivot tables define their filters in the deprecated criteria, keyed by column offset
+	offsets := make([]int64, 0, len(pivotTable.Criteria))
+	for offset := range pivotTable.Criteria {
+		if columnOffset, err := strconv.ParseInt(offset, 10, 64); err == nil {
+			offsets = append(offsets, columnOffset)
+		}
+	}
+	slices.Sort(offsets)
+	for _, columnOffset := range offsets {
+		criteria := pivotTable.Criteria[strconv.FormatInt(columnOffset, 10)]
+		info.Filters = append(info.Filters, pivotFilterInfo{
+			SourceColumn:     getSourceColumn(columnOffset, nil),
+			VisibleValues:    criteria.VisibleValues,
+			Condition:        criteria.Condition,
+			VisibleByDefault: criteria.VisibleByDefault,
+		})
+	}
+
+	return info
+}
@@ -0,0 +1,179 @@
+package googlesheets
+
+import (
+	"context"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type protectedRangeInfo = struct {
+	SheetName         string
+	Range             string
+	UnprotectedRanges []string
+	ProtectedRange    *sheets.ProtectedRange
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsProtectedRange(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_protected_range",
+		Description: "Retrieve the protected ranges and protected sheets in a spreadsheet, along with their editors.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetProtectedRanges,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protected_range_id",
+				Description: "The ID of the protected range.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ProtectedRange.ProtectedRangeId"),
+			},
+			{
+				Name:        "range",
+				Description: "The protected range in A1 notation. The range is the name of the sheet if the whole sheet is protected.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the protected range.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProtectedRange.Description"),
+			},
+			{
+				Name:        "named_range_id",
+				Description: "The ID of the named range backing the protected range, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProtectedRange.NamedRangeId"),
+			},
+			{
+				Name:        "table_id",
+				Description: "The ID of the table backing the protected range, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProtectedRange.TableId"),
+			},
+			{
+				Name:        "warning_only",
+				Description: "Indicates whether editing the range only shows a warning, instead of being restricted to the editors.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProtectedRange.WarningOnly"),
+			},
+			{
+				Name:        "requesting_user_can_edit",
+				Description: "Indicates whether the user requesting the protected range can edit it.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProtectedRange.RequestingUserCanEdit"),
+			},
+			{
+				Name:        "unprotected_ranges",
+				Description: "The ranges within a protected sheet which are unprotected, in A1 notation.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "editor_users",
+				Description: "The email addresses of the users with edit access to the protected range. Only visible to users with edit access to the protected range.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProtectedRange.Editors.Users"),
+			},
+			{
+				Name:        "editor_groups",
+				Description: "The email addresses of the groups with edit access to the protected range. Only visible to users with edit access to the protected range.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProtectedRange.Editors.Groups"),
+			},
+			{
+				Name:        "domain_users_can_edit",
+				Description: "Indicates whether anyone in the domain of the spreadsheet has edit access to the protected range.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProtectedRange.Editors.DomainUsersCanEdit"),
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetProtectedRanges(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetProtectedRanges", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Spreadsheets.Get(spreadsheetID).Fields(googleapi.Field("sheets(properties.title,protectedRanges)"))
+
+	// Additional filters
+	if ranges := getQualListValues(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range data.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		for _, protectedRange := range sheet.ProtectedRanges {
+			d.StreamListItem(ctx, getProtectedRangeInfo(sheet.Properties.Title, protectedRange))
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getProtectedRangeInfo(sheetName string, protectedRange *sheets.ProtectedRange) protectedRangeInfo {
+	info := protectedRangeInfo{
+		SheetName:      sheetName,
+		ProtectedRange: protectedRange,
+	}
+
+	// A fully unbounded range protects the whole sheet
+	if r := protectedRange.Range; r != nil {
+		info.Range = getA1Notation(sheetName, r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex)
+	}
+	for _, r := range protectedRange.UnprotectedRanges {
+		info.UnprotectedRanges = append(info.UnprotectedRanges, getA1Notation(sheetName, r.StartRowIndex, r.EndRowIndex, r.StartColumnIndex, r.EndColumnIndex))
+	}
+
+	return info
+}
@@ -0,0 +1,173 @@
+package googlesheets
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsRevision(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_revision",
+		Description: "Retrieve the revision history of the spreadsheet.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetRevisions,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:      "modified_time",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "<", "<=", "="},
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the revision.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "modified_time",
+				Description: "The last time the revision was modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_modifying_user_name",
+				Description: "The name of the last user to modify the revision.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LastModifyingUser.DisplayName"),
+			},
+			{
+				Name:        "last_modifying_user_email",
+				Description: "The email address of the last user to modify the revision, if available.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LastModifyingUser.EmailAddress"),
+			},
+			{
+				Name:        "keep_forever",
+				Description: "Indicates whether the revision is kept forever, even if it is no longer the head revision.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("KeepForever"),
+			},
+			{
+				Name:        "published",
+				Description: "Indicates whether the revision is published.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Published"),
+			},
+			{
+				Name:        "mime_type",
+				Description: "The MIME type of the revision.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "export_links",
+				Description: "Links for exporting the revision to specific formats, keyed by MIME type.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "last_modifying_user",
+				Description: "Specifies the details of the last user to modify the revision.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetRevisions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetRevisions", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	// The API can't filter revisions, so the full list is fetched once, and filtered below
+	var revisions []*drive.Revision
+	resp := svc.Revisions.List(spreadsheetID).PageSize(200).Fields("nextPageToken,revisions(*)")
+	err = resp.Pages(ctx, func(page *drive.RevisionList) error {
+		revisions = append(revisions, page.Revisions...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range revisions {
+		// Additional filters
+		if !matchesTimeQuals(revision.ModifiedTime, d.Quals["modified_time"]) {
+			continue
+		}
+
+		d.StreamListItem(ctx, revision)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Returns true if the given RFC 3339 time matches all the quals of a timestamp column
+func matchesTimeQuals(value string, timeQuals *plugin.KeyColumnQuals) bool {
+	if timeQuals == nil {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	for _, q := range timeQuals.Quals {
+		qualTime := q.Value.GetTimestampValue().AsTime()
+		switch q.Operator {
+		case quals.QualOperatorGreater:
+			if !t.After(qualTime) {
+				return false
+			}
+		case quals.QualOperatorGreaterOrEqual:
+			if t.Before(qualTime) {
+				return false
+			}
+		case quals.QualOperatorLess:
+			if !t.Before(qualTime) {
+				return false
+			}
+		case quals.QualOperatorLessOrEqual:
+			if t.After(qualTime) {
+				return false
+			}
+		case quals.QualOperatorEqual:
+			if !t.Equal(qualTime) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,265 @@
+package googlesheets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	severityCritical = "critical"
+	severityHigh     = "high"
+	severityMedium   = "medium"
+	severityLow      = "low"
+)
+
+// Domains of consumer email providers, which are never inferred as internal, since anyone can have an address in them
+var publicEmailDomains = []string{
+	"gmail.com", "googlemail.com", "outlook.com", "hotmail.com", "live.com", "msn.com", "yahoo.com", "ymail.com",
+	"icloud.com", "me.com", "mac.com", "aol.com", "proton.me", "protonmail.com", "gmx.com", "gmx.net", "mail.com",
+	"yandex.com", "zoho.com",
+}
+
+type sharingFindingInfo = struct {
+	Finding      string
+	Severity     string
+	Description  string
+	PermissionId string
+	Role         string
+	Type         string
+	Grantee      string
+}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsSharingFinding(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_sharing_finding",
+		Description: "Retrieve the risky sharing settings of the spreadsheet, e.g. public links or permissions granted outside of the internal domains.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetSharingFindings,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "finding",
+				Description: "The type of finding, e.g. public_on_web, anyone_with_link, external_user, external_group, external_domain, domain_wide_access, viewers_can_copy, writers_can_share, link_security_update_disabled, lock_not_owner_restricted or internal_domains_not_configured.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "severity",
+				Description: "The severity of the finding, either critical, high, medium or low.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "A description of the finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "permission_id",
+				Description: "The ID of the permission causing the finding, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "role",
+				Description: "The role granted by the permission causing the finding, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the grantee of the permission causing the finding, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "grantee",
+				Description: "The email address or domain the permission causing the finding is granted to, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetSharingFindings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := drive.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetSharingFindings", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	file, err := svc.Files.Get(spreadsheetID).SupportsAllDrives(true).Fields("driveId,owners,copyRequiresWriterPermission,writersCanShare,linkShareMetadata,contentRestrictions").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// All the permissions are required to evaluate the sharing settings
+	permissions, err := getSpreadsheetPermissions(ctx, svc, spreadsheetID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the domains of the owners of the spreadsheet, if no internal domains are configured
+	// Consumer domains, e.g. gmail.com, are skipped so that sharing with other consumer accounts is reported
+	googleSheetsConfig := GetConfig(d.Connection)
+	internalDomains := googleSheetsConfig.InternalDomains
+	if len(internalDomains) == 0 {
+		for _, owner := range file.Owners {
+			if domain := getEmailDomain(owner.EmailAddress); domain != "" && !slices.Contains(publicEmailDomains, domain) {
+				internalDomains = append(internalDomains, domain)
+			}
+		}
+	}
+
+	for _, finding := range getSharingFindings(file, permissions, internalDomains) {
+		d.StreamListItem(ctx, finding)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getSharingFindings returns a finding for each risky sharing setting of a spreadsheet
+// Permissions granting write access are more severe than the ones granting read or comment access
+// Files on a shared drive have no owners, so their permissions can only be classified as internal or external if internal domains are configured
+func getSharingFindings(file *drive.File, permissions []*drive.Permission, internalDomains []string) []sharingFindingInfo {
+	var findings []sharingFindingInfo
+	unknownDomains := len(internalDomains) == 0 && file.DriveId != ""
+	isInternal := func(domain string) bool {
+		return slices.ContainsFunc(internalDomains, func(internalDomain string) bool {
+			return strings.EqualFold(internalDomain, domain)
+		})
+	}
+	canWrite := func(role string) bool {
+		return slices.Contains([]string{"owner", "organizer", "fileOrganizer", "writer"}, role)
+	}
+	newFinding := func(finding string, severity string, description string, permission *drive.Permission) sharingFindingInfo {
+		info := sharingFindingInfo{
+			Finding:     finding,
+			Severity:    severity,
+			Description: description,
+		}
+		if permission != nil {
+			info.PermissionId = permission.Id
+			info.Role = permission.Role
+			info.Type = permission.Type
+			info.Grantee = permission.EmailAddress
+			if info.Grantee == "" {
+				info.Grantee = permission.Domain
+			}
+		}
+		return info
+	}
+
+	var hasLink, hasViewers, hasWriters bool
+	for _, permission := range permissions {
+		if permission.Deleted {
+			continue
+		}
+		switch permission.Role {
+		case "reader", "commenter":
+			hasViewers = true
+		case "writer":
+			hasWriters = true
+		}
+
+		switch permission.Type {
+		case "anyone":
+			hasLink = true
+			severity := severityHigh
+			if permission.AllowFileDiscovery || canWrite(permission.Role) {
+				severity = severityCritical
+			}
+			if permission.AllowFileDiscovery {
+				findings = append(findings, newFinding("public_on_web", severity, fmt.Sprintf("The spreadsheet is public on the web, and anyone can find it with the %s role.", permission.Role), permission))
+			} else {
+				findings = append(findings, newFinding("anyone_with_link", severity, fmt.Sprintf("Anyone with the link can access the spreadsheet with the %s role.", permission.Role), permission))
+			}
+		case "domain":
+			if unknownDomains {
+				continue
+			}
+			if isInternal(permission.Domain) {
+				if canWrite(permission.Role) {
+					findings = append(findings, newFinding("domain_wide_access", severityLow, fmt.Sprintf("Anyone in the internal domain %s can edit the spreadsheet.", permission.Domain), permission))
+				}
+				continue
+			}
+			severity := severityMedium
+			if canWrite(permission.Role) {
+				severity = severityHigh
+			}
+			findings = append(findings, newFinding("external_domain", severity, fmt.Sprintf("Anyone in the external domain %s can access the spreadsheet with the %s role.", permission.Domain, permission.Role), permission))
+		case "user", "group":
+			// The owners of the spreadsheet aren't shared with, even if their domain isn't internal, e.g. gmail.com
+			if unknownDomains || permission.Role == "owner" {
+				continue
+			}
+			domain := getEmailDomain(permission.EmailAddress)
+			if domain == "" || isInternal(domain) {
+				continue
+			}
+			severity := severityMedium
+			if canWrite(permission.Role) {
+				severity = severityHigh
+			}
+			findings = append(findings, newFinding("external_"+permission.Type, severity, fmt.Sprintf("The external %s %s can access the spreadsheet with the %s role.", permission.Type, permission.EmailAddress, permission.Role), permission))
+		}
+	}
+
+	if unknownDomains {
+		findings = append(findings, newFinding("internal_domains_not_configured", severityLow, "The spreadsheet is on a shared drive, which has no owners to infer the internal domains from. Permissions granted to users, groups and domains are not evaluated until internal_domains is configured.", nil))
+	}
+	if hasViewers && !file.CopyRequiresWriterPermission {
+		findings = append(findings, newFinding("viewers_can_copy", severityLow, "Readers and commenters can copy, print and download the spreadsheet.", nil))
+	}
+	if hasWriters && file.WritersCanShare {
+		findings = append(findings, newFinding("writers_can_share", severityLow, "Users with the writer role can change the permissions of the spreadsheet.", nil))
+	}
+	if hasLink && file.LinkShareMetadata != nil && file.LinkShareMetadata.SecurityUpdateEligible && !file.LinkShareMetadata.SecurityUpdateEnabled {
+		findings = append(findings, newFinding("link_security_update_disabled", severityMedium, "The link of the spreadsheet is shared, but the security update preventing access with older links is not applied.", nil))
+	}
+	for _, restriction := range file.ContentRestrictions {
+		if restriction.ReadOnly && !restriction.OwnerRestricted && hasWriters {
+			findings = append(findings, newFinding("lock_not_owner_restricted", severityLow, "The spreadsheet is locked, but any user with the writer role can unlock it.", nil))
+		}
+	}
+
+	return findings
+}
+
+// Returns the lower case domain of an email address, e.g. example.com for user@Example.com
+func getEmailDomain(email string) string {
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return ""
+	}
+	return strings.ToLower(email[idx+1:])
+}
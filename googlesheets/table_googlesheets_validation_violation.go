@@ -0,0 +1,448 @@
+package googlesheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type validationViolationInfo = struct {
+	SheetName string
+	Column    string
+	Row       int
+	Cell      string
+	Value     string
+	Reason    string
+	Rule      *sheets.DataValidationRule
+}
+
+// Layouts accepted for dates entered in the values of a date condition
+// Ambiguous dates are read month first, e.g. 1/2/2024 is January 2nd
+var conditionDateLayouts = []string{"2006-01-02", "1/2/2006", "2/1/2006", "2.1.2006", "2006/01/02"}
+
+//// TABLE DEFINITION
+
+func tableGoogleSheetsValidationViolation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "googlesheets_validation_violation",
+		Description: "Retrieve the cells of a spreadsheet whose value fails their data validation rule.",
+		List: &plugin.ListConfig{
+			Hydrate: listGoogleSheetValidationViolations,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "sheet_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "sheet_name",
+				Description: "The name of the sheet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "col",
+				Description: "The ID of the column.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Column"),
+			},
+			{
+				Name:        "row",
+				Description: "The index of the row.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "cell",
+				Description: "The address of the cell.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "value",
+				Description: "The value of the cell.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reason",
+				Description: "The reason the value fails the data validation rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "condition_type",
+				Description: "The type of condition the value of the cell must match, e.g. ONE_OF_LIST, NUMBER_BETWEEN or DATE_IS_VALID.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Rule.Condition.Type"),
+			},
+			{
+				Name:        "condition_values",
+				Description: "The values of the condition, either user entered values or relative dates.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Rule.Condition").Transform(conditionValues),
+			},
+			{
+				Name:        "strict",
+				Description: "Indicates whether invalid data is rejected by the rule. Violations of strict rules can only be introduced by formulas, or by changing the rule after the data has been entered.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Rule.Strict"),
+			},
+			{
+				Name:        "rule",
+				Description: "The data validation rule of the cell.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spreadsheet_id",
+				Description: "The ID of the spreadsheet.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     spreadsheetID,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listGoogleSheetValidationViolations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	opts, err := getSessionConfigStatic(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create service
+	svc, err := sheets.NewService(ctx, opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("listGoogleSheetValidationViolations", "connection_error", err)
+		return nil, err
+	}
+
+	spreadsheetID := getSpreadsheetIDStatic(ctx, d)
+
+	resp := svc.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(googleapi.Field("properties(timeZone,locale),sheets(properties.title,data(rowData(values(formattedValue,effectiveValue,effectiveFormat/numberFormat,dataValidation)),startColumn,startRow))"))
+
+	// Additional filters
+	if ranges := getQualListValues(d.EqualsQuals); len(ranges) > 0 {
+		resp.Ranges(ranges...)
+	}
+
+	data, err := resp.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates are interpreted using the spreadsheet's time zone and locale
+	settings := newSpreadsheetSettings(data.Properties)
+
+	// Get the values of the ranges used by ONE_OF_RANGE rules
+	var sourceRanges []string
+	iterateCells(data, func(sheetName string, _ int, _ int, cell *sheets.CellData) bool {
+		if sourceRange := getSourceRange(sheetName, cell.DataValidation); sourceRange != "" && !slices.Contains(sourceRanges, sourceRange) {
+			sourceRanges = append(sourceRanges, sourceRange)
+		}
+		return true
+	})
+	rangeValues, err := getSourceRangeValues(ctx, svc, spreadsheetID, sourceRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cells are only evaluated if they have a value, since data validation doesn't apply to empty cells
+	iterateCells(data, func(sheetName string, rowCount int, colCount int, cell *sheets.CellData) bool {
+		if cell.DataValidation == nil || cell.DataValidation.Condition == nil {
+			return true
+		}
+		reason := getValidationViolation(sheetName, cell, cell.DataValidation.Condition, settings, rangeValues)
+		if reason == "" {
+			return true
+		}
+
+		d.StreamListItem(ctx, validationViolationInfo{
+			SheetName: sheetName,
+			Column:    intToLetters(colCount + 1),
+			Row:       rowCount + 1,
+			Cell:      fmt.Sprintf("%s%d", intToLetters(colCount+1), rowCount+1),
+			Value:     cell.FormattedValue,
+			Reason:    reason,
+			Rule:      cell.DataValidation,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+
+	return nil, nil
+}
+
+// getValidationViolation evaluates the value of a cell against the condition of its data validation rule
+// Returns the reason the value fails the condition, or an empty string if the value is valid
+// Conditions which can't be evaluated locally (e.g. CUSTOM_FORMULA, or values referencing other cells) are considered valid
+func getValidationViolation(sheetName string, cell *sheets.CellData, condition *sheets.BooleanCondition, settings *spreadsheetSettings, rangeValues map[string][]string) string {
+	value := cell.FormattedValue
+	values := getConditionValues(condition)
+
+	switch condition.Type {
+	case "ONE_OF_LIST":
+		if !containsFold(values, value) {
+			// Multi-select dropdowns hold the selected values separated by commas
+			for _, item := range strings.Split(value, ",") {
+				if !containsFold(values, strings.TrimSpace(item)) {
+					return "Value is not in the list of allowed values."
+				}
+			}
+		}
+	case "ONE_OF_RANGE":
+		sourceRange := getSourceRange(sheetName, cell.DataValidation)
+		if allowed, ok := rangeValues[sourceRange]; ok && !containsFold(allowed, value) {
+			return fmt.Sprintf("Value is not listed in the range %s.", sourceRange)
+		}
+	case "BOOLEAN":
+		switch len(values) {
+		case 0:
+			if cell.EffectiveValue == nil || cell.EffectiveValue.BoolValue == nil {
+				return "Value is not TRUE or FALSE."
+			}
+		case 1:
+			if !strings.EqualFold(values[0], value) {
+				return fmt.Sprintf("Value is not the checked value %s, or empty.", values[0])
+			}
+		default:
+			if !containsFold(values[:2], value) {
+				return fmt.Sprintf("Value is not the checked value %s, or the unchecked value %s.", values[0], values[1])
+			}
+		}
+	case "NUMBER_GREATER", "NUMBER_GREATER_THAN_EQ", "NUMBER_LESS", "NUMBER_LESS_THAN_EQ", "NUMBER_EQ", "NUMBER_NOT_EQ", "NUMBER_BETWEEN", "NUMBER_NOT_BETWEEN":
+		if cell.EffectiveValue == nil || cell.EffectiveValue.NumberValue == nil {
+			return "Value is not a number."
+		}
+		limits, ok := parseConditionNumbers(values, settings)
+		if !ok {
+			return ""
+		}
+		if !compareConditionValue(condition.Type, "NUMBER_", *cell.EffectiveValue.NumberValue, limits) {
+			return fmt.Sprintf("Value doesn't match the condition %s %s.", condition.Type, strings.Join(values, ", "))
+		}
+	case "DATE_IS_VALID":
+		if cell.EffectiveValue == nil || cell.EffectiveValue.NumberValue == nil || !isDateFormat(getNumberFormatType(cell)) {
+			return "Value is not a valid date."
+		}
+	case "DATE_EQ", "DATE_BEFORE", "DATE_AFTER", "DATE_ON_OR_BEFORE", "DATE_ON_OR_AFTER", "DATE_BETWEEN", "DATE_NOT_BETWEEN":
+		if cell.EffectiveValue == nil || cell.EffectiveValue.NumberValue == nil || !isDateFormat(getNumberFormatType(cell)) {
+			return "Value is not a valid date."
+		}
+		limits, ok := parseConditionDates(values, settings)
+		if !ok {
+			return ""
+		}
+
+		// Dates are compared by day, ignoring the time of the day
+		if !compareConditionValue(condition.Type, "DATE_", math.Floor(*cell.EffectiveValue.NumberValue), limits) {
+			return fmt.Sprintf("Value doesn't match the condition %s %s.", condition.Type, strings.Join(values, ", "))
+		}
+	case "TEXT_CONTAINS":
+		if len(values) > 0 && !strings.Contains(strings.ToLower(value), strings.ToLower(values[0])) {
+			return fmt.Sprintf("Value doesn't contain %s.", values[0])
+		}
+	case "TEXT_NOT_CONTAINS":
+		if len(values) > 0 && strings.Contains(strings.ToLower(value), strings.ToLower(values[0])) {
+			return fmt.Sprintf("Value contains %s.", values[0])
+		}
+	case "TEXT_EQ":
+		if len(values) > 0 && !strings.EqualFold(value, values[0]) {
+			return fmt.Sprintf("Value is not %s.", values[0])
+		}
+	case "TEXT_IS_EMAIL":
+		if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+			return "Value is not a valid email address."
+		}
+	case "TEXT_IS_URL":
+		if u, err := url.Parse(value); err != nil || u.Host == "" {
+			if u, err := url.Parse("https://" + value); err != nil || !strings.Contains(u.Host, ".") {
+				return "Value is not a valid URL."
+			}
+		}
+	}
+
+	return ""
+}
+
+// getSourceRange returns the range holding the allowed values of a ONE_OF_RANGE rule, or an empty string for other rules
+// Ranges without a sheet name, e.g. =$A$1:$A$10, refer to the sheet of the rule, whereas the API would read them from the first sheet
+func getSourceRange(sheetName string, rule *sheets.DataValidationRule) string {
+	if rule == nil || rule.Condition == nil || rule.Condition.Type != "ONE_OF_RANGE" || len(rule.Condition.Values) == 0 {
+		return ""
+	}
+	sourceRange := strings.TrimPrefix(rule.Condition.Values[0].UserEnteredValue, "=")
+	if isA1Cells(sourceRange) {
+		return fmt.Sprintf("%s!%s", quoteSheetName(sheetName), sourceRange)
+	}
+	return sourceRange
+}
+
+// getSourceRangeValues returns the formatted values of the given ranges, keyed by range
+// Ranges which can't be read, e.g. because their sheet has been deleted, are skipped, so that the rules using them are considered valid
+func getSourceRangeValues(ctx context.Context, svc *sheets.Service, spreadsheetID string, sourceRanges []string) (map[string][]string, error) {
+	rangeValues := map[string][]string{}
+
+	// References to deleted ranges can't be read
+	sourceRanges = slices.DeleteFunc(slices.Clone(sourceRanges), func(sourceRange string) bool {
+		return strings.Contains(sourceRange, "#REF!")
+	})
+	if len(sourceRanges) == 0 {
+		return rangeValues, nil
+	}
+
+	addValues := func(sourceRange string, valueRange *sheets.ValueRange) {
+		// Empty ranges allow no value at all
+		rangeValues[sourceRange] = []string{}
+		for _, row := range valueRange.Values {
+			for _, value := range row {
+				rangeValues[sourceRange] = append(rangeValues[sourceRange], fmt.Sprint(value))
+			}
+		}
+	}
+
+	resp, err := svc.Spreadsheets.Values.BatchGet(spreadsheetID).ValueRenderOption("FORMATTED_VALUE").Ranges(sourceRanges...).Fields(googleapi.Field("valueRanges")).Context(ctx).Do()
+	if err == nil {
+		for idx, valueRange := range resp.ValueRanges {
+			if idx < len(sourceRanges) {
+				addValues(sourceRanges[idx], valueRange)
+			}
+		}
+		return rangeValues, nil
+	}
+
+	// A single invalid range fails the whole batch, so the ranges are read one by one to skip the invalid ones
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		return nil, err
+	}
+	for _, sourceRange := range sourceRanges {
+		valueRange, err := svc.Spreadsheets.Values.Get(spreadsheetID, sourceRange).ValueRenderOption("FORMATTED_VALUE").Fields(googleapi.Field("values")).Context(ctx).Do()
+		if err != nil {
+			if errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest {
+				plugin.Logger(ctx).Warn("getSourceRangeValues", "range", sourceRange, "invalid_range", err)
+				continue
+			}
+			return nil, err
+		}
+		addValues(sourceRange, valueRange)
+	}
+
+	return rangeValues, nil
+}
+
+// compareConditionValue compares a value against the limits of a NUMBER_* or DATE_* condition
+func compareConditionValue(conditionType string, prefix string, value float64, limits []float64) bool {
+	operator := strings.TrimPrefix(conditionType, prefix)
+	switch operator {
+	case "BETWEEN", "NOT_BETWEEN":
+		if len(limits) < 2 {
+			return true
+		}
+		low, high := min(limits[0], limits[1]), max(limits[0], limits[1])
+		between := value >= low && value <= high
+		return between == (operator == "BETWEEN")
+	}
+
+	if len(limits) < 1 {
+		return true
+	}
+	switch operator {
+	case "GREATER", "AFTER":
+		return value > limits[0]
+	case "GREATER_THAN_EQ", "ON_OR_AFTER":
+		return value >= limits[0]
+	case "LESS", "BEFORE":
+		return value < limits[0]
+	case "LESS_THAN_EQ", "ON_OR_BEFORE":
+		return value <= limits[0]
+	case "EQ":
+		return value == limits[0]
+	case "NOT_EQ":
+		return value != limits[0]
+	}
+	return true
+}
+
+// parseConditionNumbers parses the values of a NUMBER_* condition
+// Returns false if any of the values can't be parsed, e.g. if it's a formula referencing other cells
+func parseConditionNumbers(values []string, settings *spreadsheetSettings) ([]float64, bool) {
+	var numbers []float64
+	for _, value := range values {
+		number, err := parseLocaleNumber(value, settings.DecimalSeparator)
+		if err != nil {
+			return nil, false
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, true
+}
+
+// parseConditionDates parses the values of a DATE_* condition into serial day numbers
+// Values can either be relative dates (e.g. TODAY or PAST_WEEK), dates, or serial numbers
+// Returns false if any of the values can't be parsed, e.g. if it's a formula referencing other cells
+func parseConditionDates(values []string, settings *spreadsheetSettings) ([]float64, bool) {
+	now := time.Now().In(settings.Location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, settings.Location)
+
+	var dates []float64
+	for _, value := range values {
+		var date time.Time
+		switch value {
+		case "TODAY":
+			date = today
+		case "YESTERDAY":
+			date = today.AddDate(0, 0, -1)
+		case "TOMORROW":
+			date = today.AddDate(0, 0, 1)
+		case "PAST_WEEK":
+			date = today.AddDate(0, 0, -7)
+		case "PAST_MONTH":
+			date = today.AddDate(0, -1, 0)
+		case "PAST_YEAR":
+			date = today.AddDate(-1, 0, 0)
+		default:
+			if serial, err := parseLocaleNumber(value, settings.DecimalSeparator); err == nil {
+				dates = append(dates, math.Floor(serial))
+				continue
+			}
+			parsed := false
+			for _, layout := range conditionDateLayouts {
+				if t, err := time.ParseInLocation(layout, value, settings.Location); err == nil {
+					date, parsed = t, true
+					break
+				}
+			}
+			if !parsed {
+				return nil, false
+			}
+		}
+		dates = append(dates, math.Floor(timeToSerial(date, settings.Location)))
+	}
+	return dates, true
+}
+
+// Returns true if the list contains the value, ignoring the case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,22 @@ package googlesheets
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/mitchellh/go-homedir"
+	"google.golang.org/api/sheets/v4"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
@@ -105,6 +117,133 @@ func intToLetters(colIndex int) (letter string) {
 	return
 }
 
+// Converts column letters into their 1-based index, e.g. A is 1 and AA is 27
+func lettersToInt(letters string) int {
+	var colIndex int
+	for _, r := range strings.ToUpper(letters) {
+		colIndex = colIndex*26 + int(r-'A') + 1
+	}
+	return colIndex
+}
+
+// Returns the sheet name as used in A1 notation
+// Names containing anything other than letters, digits and underscores are wrapped in single quotes, e.g. 'Sheet 1'
+// So are names which would otherwise be read as cells, e.g. 'Q1' or 'R1C1'
+func quoteSheetName(sheetName string) string {
+	if isA1Cells(sheetName) || r1c1CellsPattern.MatchString(sheetName) {
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(sheetName, "'", "''"))
+	}
+	for _, r := range sheetName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return fmt.Sprintf("'%s'", strings.ReplaceAll(sheetName, "'", "''"))
+		}
+	}
+	return sheetName
+}
+
+// The number of columns of the widest sheet, i.e. up to column ZZZ
+const maxColumns = 18278
+
+// Converts the zero-based, end exclusive indexes of a range into A1 notation, e.g. Sheet1!A1:B2
+// An end index of 0 means the range is unbounded, i.e. whole columns (Sheet1!A:B) or whole rows (Sheet1!1:2)
+func getA1Notation(sheetName string, startRow int64, endRow int64, startColumn int64, endColumn int64) string {
+	if startRow == 0 && endRow == 0 && startColumn == 0 && endColumn == 0 { // whole sheet
+		return quoteSheetName(sheetName)
+	}
+
+	// A1 notation can't leave the end column open once the start column is set, so the range ends at the last column instead
+	if endColumn == 0 && (startColumn > 0 || endRow == 0) {
+		endColumn = maxColumns
+	}
+
+	var a1Range string
+	switch {
+	case endRow == 0: // whole columns
+		if startRow == 0 {
+			a1Range = fmt.Sprintf("%s:%s", intToLetters(int(startColumn)+1), intToLetters(int(endColumn)))
+		} else {
+			a1Range = fmt.Sprintf("%s%d:%s", intToLetters(int(startColumn)+1), startRow+1, intToLetters(int(endColumn)))
+		}
+	case endColumn == 0: // whole rows
+		a1Range = fmt.Sprintf("%d:%d", startRow+1, endRow)
+	case endRow-startRow == 1 && endColumn-startColumn == 1: // single cell
+		a1Range = fmt.Sprintf("%s%d", intToLetters(int(startColumn)+1), startRow+1)
+	default:
+		a1Range = fmt.Sprintf("%s%d:%s%d", intToLetters(int(startColumn)+1), startRow+1, intToLetters(int(endColumn)), endRow)
+	}
+
+	if sheetName == "" {
+		return a1Range
+	}
+	return fmt.Sprintf("%s!%s", quoteSheetName(sheetName), a1Range)
+}
+
+// Patterns of the cells of a range, e.g. A1:B2, A:B, 1:2 or A2:B in A1 notation, and R1C1:R2C2 in R1C1 notation
+var (
+	a1CellsPattern   = regexp.MustCompile(`^\$?([A-Za-z]*)\$?([0-9]*)(?::\$?([A-Za-z]*)\$?([0-9]*))?$`)
+	r1c1CellsPattern = regexp.MustCompile(`^[Rr][0-9]*[Cc][0-9]*(?::[Rr][0-9]*[Cc][0-9]*)?$`)
+)
+
+// Returns true if the given text is a reference to cells in A1 notation without a sheet name, e.g. A1, $A$1:$B$2, A:B or 1:2
+// Column letters are limited to 3, the widest column of a sheet being ZZZ, so that names such as Sheet1 aren't mistaken for cells
+func isA1Cells(a1 string) bool {
+	match := a1CellsPattern.FindStringSubmatch(a1)
+	if match == nil || !strings.ContainsAny(a1, "0123456789:") {
+		return false
+	}
+	return len(match[1]) <= 3 && len(match[3]) <= 3
+}
+
+// Parses a range in A1 notation into the name of its sheet and its zero-based, end exclusive indexes, as opposed to getA1Notation
+// The range is nil if it refers to a whole sheet, and the sheet name is empty if the range doesn't include one, e.g. A1:B2
+func parseA1Notation(a1 string) (string, *sheets.GridRange, error) {
+	sheetName, cells := a1, ""
+	if idx := strings.LastIndex(a1, "!"); idx >= 0 {
+		sheetName, cells = a1[:idx], a1[idx+1:]
+	} else if isA1Cells(a1) || r1c1CellsPattern.MatchString(a1) {
+		sheetName, cells = "", a1
+	}
+
+	// Quoted sheet names escape single quotes by doubling them, e.g. 'John''s sheet'
+	if len(sheetName) >= 2 && sheetName[0] == '\'' && sheetName[len(sheetName)-1] == '\'' {
+		sheetName = strings.ReplaceAll(sheetName[1:len(sheetName)-1], "''", "'")
+	}
+	if cells == "" {
+		return sheetName, nil, nil
+	}
+
+	if r1c1CellsPattern.MatchString(cells) {
+		return "", nil, errors.New("ranges in R1C1 notation are not supported, use A1 notation instead")
+	}
+	match := a1CellsPattern.FindStringSubmatch(cells)
+	if match == nil {
+		return "", nil, fmt.Errorf("invalid range %q", a1)
+	}
+	startColumn, startRow, endColumn, endRow := match[1], match[2], match[3], match[4]
+
+	// A single cell, e.g. A1, is both the start and the end of the range
+	if !strings.Contains(cells, ":") {
+		endColumn, endRow = startColumn, startRow
+	}
+	gridRange := &sheets.GridRange{}
+	if startColumn != "" {
+		gridRange.StartColumnIndex = int64(lettersToInt(startColumn) - 1)
+	}
+	if endColumn != "" {
+		gridRange.EndColumnIndex = int64(lettersToInt(endColumn))
+	}
+	if startRow != "" {
+		row, _ := strconv.ParseInt(startRow, 10, 64)
+		gridRange.StartRowIndex = max(row-1, 0)
+	}
+	if endRow != "" {
+		row, _ := strconv.ParseInt(endRow, 10, 64)
+		gridRange.EndRowIndex = row
+	}
+
+	return sheetName, gridRange, nil
+}
+
 // Return the maximum length of a column in a sheet
 func getMaxLength(values [][]interface{}) int {
 	var maxColsLength int
@@ -116,6 +255,54 @@ func getMaxLength(values [][]interface{}) int {
 	return maxColsLength
 }
 
+// Returns the rows of the given sheet from a spreadsheet fetched with grid data
+func getSheetRowData(spreadsheet *sheets.Spreadsheet, sheetName string) []*sheets.RowData {
+	if spreadsheet == nil {
+		return nil
+	}
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == sheetName && len(sheet.Data) > 0 {
+			return sheet.Data[0].RowData
+		}
+	}
+	return nil
+}
+
+// Returns the merges of a sheet relative to the given grid data, i.e. the first cell of the data is at row index 0, column index 0
+// This is required to look up merges in the data of a range which doesn't start at A1, e.g. a named range
+func getRelativeMerges(merges []*sheets.GridRange, data *sheets.GridData) []*sheets.GridRange {
+	if data == nil || (data.StartRow == 0 && data.StartColumn == 0) {
+		return merges
+	}
+	relativeMerges := make([]*sheets.GridRange, 0, len(merges))
+	for _, merge := range merges {
+		relativeMerges = append(relativeMerges, &sheets.GridRange{
+			SheetId:          merge.SheetId,
+			StartRowIndex:    merge.StartRowIndex - data.StartRow,
+			EndRowIndex:      merge.EndRowIndex - data.StartRow,
+			StartColumnIndex: merge.StartColumnIndex - data.StartColumn,
+			EndColumnIndex:   merge.EndColumnIndex - data.StartColumn,
+		})
+	}
+	return relativeMerges
+}
+
+// Returns true if any of the given columns is requested in the query
+func isColumnRequested(d *plugin.QueryData, columns ...string) bool {
+	for _, column := range d.QueryContext.Columns {
+		if slices.Contains(columns, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// Converts a color into its hex string, e.g. #ff0000
+// Missing components are set to 0, since the API omits them from the response
+func colorToHex(color *sheets.Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", int(math.Round(color.Red*255)), int(math.Round(color.Green*255)), int(math.Round(color.Blue*255)))
+}
+
 func getQualListValues(quals map[string]*proto.QualValue) []string {
 	if quals["sheet_name"].GetStringValue() != "" {
 		return []string{quals["sheet_name"].GetStringValue()}
@@ -129,3 +316,15 @@ func getQualListValues(quals map[string]*proto.QualValue) []string {
 
 	return nil
 }
+
+// getRowHash returns a deterministic hash of the formatted values of a row, in column order
+// The names of the columns aren't hashed, so that renaming a header doesn't change the hash of the rows
+func getRowHash(values []string) string {
+	// Empty cells at the end of a row are omitted by the API, so they are trimmed to hash the same row the same way
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	data, _ := json.Marshal(values)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,249 @@
+package googlesheets
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestParseA1Notation(t *testing.T) {
+	tests := []struct {
+		name          string
+		a1            string
+		wantSheetName string
+		wantRange     *sheets.GridRange
+		wantErr       bool
+	}{
+		{
+			name:          "sheet name",
+			a1:            "Sheet1",
+			wantSheetName: "Sheet1",
+		},
+		{
+			name:          "sheet name with whitespace",
+			a1:            "Data Sheet",
+			wantSheetName: "Data Sheet",
+		},
+		{
+			name:          "sheet name longer than the widest column",
+			a1:            "Summary2024",
+			wantSheetName: "Summary2024",
+		},
+		{
+			name:      "cell without sheet name",
+			a1:        "Q1",
+			wantRange: &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 1, StartColumnIndex: 16, EndColumnIndex: 17},
+		},
+		{
+			name:      "cell with two column letters",
+			a1:        "FY2024",
+			wantRange: &sheets.GridRange{StartRowIndex: 2023, EndRowIndex: 2024, StartColumnIndex: 180, EndColumnIndex: 181},
+		},
+		{
+			name:      "absolute range without sheet name",
+			a1:        "$A$1:$B$2",
+			wantRange: &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 0, EndColumnIndex: 2},
+		},
+		{
+			name:          "range",
+			a1:            "Sheet1!A1:B2",
+			wantSheetName: "Sheet1",
+			wantRange:     &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 0, EndColumnIndex: 2},
+		},
+		{
+			name:          "single cell",
+			a1:            "Sheet1!C3",
+			wantSheetName: "Sheet1",
+			wantRange:     &sheets.GridRange{StartRowIndex: 2, EndRowIndex: 3, StartColumnIndex: 2, EndColumnIndex: 3},
+		},
+		{
+			name:          "whole columns of a quoted sheet name",
+			a1:            "'Sheet 1'!A:B",
+			wantSheetName: "Sheet 1",
+			wantRange:     &sheets.GridRange{StartColumnIndex: 0, EndColumnIndex: 2},
+		},
+		{
+			name:          "whole rows of a sheet name with an escaped quote",
+			a1:            "'John''s sheet'!1:2",
+			wantSheetName: "John's sheet",
+			wantRange:     &sheets.GridRange{StartRowIndex: 0, EndRowIndex: 2},
+		},
+		{
+			name:          "columns from a given row",
+			a1:            "Sheet1!A2:B",
+			wantSheetName: "Sheet1",
+			wantRange:     &sheets.GridRange{StartRowIndex: 1, StartColumnIndex: 0, EndColumnIndex: 2},
+		},
+		{
+			name:          "quoted sheet name only",
+			a1:            "'Sheet 1'",
+			wantSheetName: "Sheet 1",
+		},
+		{
+			name:    "R1C1 notation",
+			a1:      "Students!R1C1:R5C1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid range",
+			a1:      "Sheet1!A-1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheetName, gridRange, err := parseA1Notation(tt.a1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseA1Notation(%q) error = %v, wantErr %v", tt.a1, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if sheetName != tt.wantSheetName {
+				t.Errorf("parseA1Notation(%q) sheet name = %q, want %q", tt.a1, sheetName, tt.wantSheetName)
+			}
+			if !reflect.DeepEqual(gridRange, tt.wantRange) {
+				t.Errorf("parseA1Notation(%q) range = %+v, want %+v", tt.a1, gridRange, tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestGetA1Notation(t *testing.T) {
+	tests := []struct {
+		name        string
+		sheetName   string
+		startRow    int64
+		endRow      int64
+		startColumn int64
+		endColumn   int64
+		want        string
+		// The end column of the parsed range, if it differs from the end column of an open range
+		wantEndColumn int64
+	}{
+		{
+			name:      "whole sheet",
+			sheetName: "Sheet1",
+			want:      "Sheet1",
+		},
+		{
+			name:      "whole sheet with whitespace",
+			sheetName: "Sheet 1",
+			want:      "'Sheet 1'",
+		},
+		{
+			name:      "range",
+			sheetName: "Sheet1",
+			endRow:    2,
+			endColumn: 2,
+			want:      "Sheet1!A1:B2",
+		},
+		{
+			name:      "single cell",
+			sheetName: "Sheet1",
+			endRow:    1,
+			endColumn: 1,
+			want:      "Sheet1!A1",
+		},
+		{
+			name:      "whole columns",
+			sheetName: "Sheet1",
+			endColumn: 2,
+			want:      "Sheet1!A:B",
+		},
+		{
+			name:      "columns from a given row",
+			sheetName: "Sheet1",
+			startRow:  1,
+			endColumn: 2,
+			want:      "Sheet1!A2:B",
+		},
+		{
+			name:      "whole rows",
+			sheetName: "Sheet1",
+			endRow:    2,
+			want:      "Sheet1!1:2",
+		},
+		{
+			name:        "cell without sheet name",
+			startRow:    2,
+			endRow:      3,
+			startColumn: 26,
+			endColumn:   27,
+			want:        "AA3",
+		},
+		{
+			name:          "rows from a given row",
+			sheetName:     "Sheet1",
+			startRow:      4,
+			want:          "Sheet1!A5:ZZZ",
+			wantEndColumn: maxColumns,
+		},
+		{
+			name:          "columns from a given column",
+			sheetName:     "Sheet1",
+			startColumn:   2,
+			want:          "Sheet1!C:ZZZ",
+			wantEndColumn: maxColumns,
+		},
+		{
+			name:          "rows from a given column",
+			sheetName:     "Sheet1",
+			endRow:        2,
+			startColumn:   2,
+			want:          "Sheet1!C1:ZZZ2",
+			wantEndColumn: maxColumns,
+		},
+		{
+			name:      "whole sheet named like a cell",
+			sheetName: "Q1",
+			want:      "'Q1'",
+		},
+		{
+			name:      "sheet named like a cell with two column letters",
+			sheetName: "FY2024",
+			endRow:    1,
+			endColumn: 1,
+			want:      "'FY2024'!A1",
+		},
+		{
+			name:      "sheet named like a cell in R1C1 notation",
+			sheetName: "R1C1",
+			want:      "'R1C1'",
+		},
+		{
+			name:      "sheet name with a quote",
+			sheetName: "John's sheet",
+			endRow:    1,
+			endColumn: 1,
+			want:      "'John''s sheet'!A1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getA1Notation(tt.sheetName, tt.startRow, tt.endRow, tt.startColumn, tt.endColumn)
+			if got != tt.want {
+				t.Errorf("getA1Notation() = %q, want %q", got, tt.want)
+			}
+
+			// Ranges in A1 notation are parsed back into the same sheet name and indexes
+			sheetName, gridRange, err := parseA1Notation(got)
+			if err != nil {
+				t.Fatalf("parseA1Notation(%q) error = %v", got, err)
+			}
+			if sheetName != tt.sheetName {
+				t.Errorf("parseA1Notation(%q) sheet name = %q, want %q", got, sheetName, tt.sheetName)
+			}
+			endColumn := tt.endColumn
+			if tt.wantEndColumn != 0 {
+				endColumn = tt.wantEndColumn
+			}
+			if gridRange != nil && (gridRange.StartRowIndex != tt.startRow || gridRange.EndRowIndex != tt.endRow || gridRange.StartColumnIndex != tt.startColumn || gridRange.EndColumnIndex != endColumn) {
+				t.Errorf("parseA1Notation(%q) range = %+v, want rows %d-%d and columns %d-%d", got, gridRange, tt.startRow, tt.endRow, tt.startColumn, endColumn)
+			}
+		})
+	}
+}